@@ -0,0 +1,68 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// CheckQuotas looks up the price table
+// only if a spend limit is configured
+func CheckQuotas(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	request entities.QuotasCheckRequest,
+	now time.Time,
+) error {
+
+	var priceTable *entities.PriceTable
+
+	if elevenConfig.HasSpendLimit(request.ClusterName) {
+		table, err := cloudService.LookupPriceTable(stepper)
+
+		if err != nil {
+			return err
+		}
+
+		priceTable = table
+	}
+
+	return elevenConfig.CheckQuotas(
+		request,
+		priceTable,
+		now,
+	)
+}
+
+// CheckClusterNamespaceQuotas looks up the price table
+// only if the namespace has a spend limit
+func CheckClusterNamespaceQuotas(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	namespace *entities.Namespace,
+	now time.Time,
+) error {
+
+	var priceTable *entities.PriceTable
+
+	if namespace.Quotas.HasSpendLimit() {
+		table, err := cloudService.LookupPriceTable(stepper)
+
+		if err != nil {
+			return err
+		}
+
+		priceTable = table
+	}
+
+	return elevenConfig.CheckClusterNamespaceQuotas(
+		cluster,
+		namespace.Name,
+		priceTable,
+		now,
+	)
+}
@@ -35,7 +35,7 @@ func CreateCluser(
 		return createClusterErr
 	}
 
-	cluster.Status = entities.ClusterStatusCreated
+	cluster.SetStatus(entities.ClusterStatusCreated)
 	return UpdateClusterInConfig(
 		stepper,
 		cloudService,
@@ -0,0 +1,34 @@
+package actions
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func CreateDeployKeys(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	deployKeysCreator entities.GitHubDeployKeysCreator,
+) error {
+
+	createDeployKeysErr := deployKeysCreator.Create(env)
+
+	// "createDeployKeysErr" is not handled first
+	// in order to be able to save the created keys IDs
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return createDeployKeysErr
+}
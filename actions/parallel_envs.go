@@ -0,0 +1,380 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+const DefaultMaxConcurrentEnvOperations = 4
+
+// EnvTarget identifies an env on which an operation is run
+type EnvTarget struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+}
+
+// EnvOperation is an operation run on one env by RunEnvOperationsInParallel.
+// The passed config is a copy owned by the operation.
+type EnvOperation func(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error
+
+type EnvOperationsOptions struct {
+	// MaxConcurrency defaults to DefaultMaxConcurrentEnvOperations
+	MaxConcurrency int
+	// BatchConfigWrites saves the config only once, when all the
+	// operations are done, instead of after each env update.
+	// Removals must not be batched given that a crash midway
+	// would leave removed infrastructure recorded as created.
+	BatchConfigWrites bool
+}
+
+type EnvOperationResult struct {
+	ClusterName string
+	EnvName     string
+	Error       error
+}
+
+// RunEnvOperationsInParallel runs the passed operation on each target
+// with, at most, "MaxConcurrency" operations running at the same time.
+//
+// Each operation works on its own copy of the config. Each time an
+// operation saves its copy, the state of its env is merged in the
+// passed config which is then saved (or only marked as modified when
+// writes are batched). That way, concurrent operations never
+// overwrite each other's changes. Saves that modify the config
+// outside of the env are rejected with ErrEnvOperationOutOfScope.
+//
+// The passed stepper is shared by all the operations
+// so its calls are serialized.
+//
+// Results are returned in the same order than the targets. The
+// returned error is only set when the batched write has failed.
+func RunEnvOperationsInParallel(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	targets []EnvTarget,
+	options EnvOperationsOptions,
+	operation EnvOperation,
+) ([]EnvOperationResult, error) {
+
+	maxConcurrency := options.MaxConcurrency
+
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrentEnvOperations
+	}
+
+	results := make([]EnvOperationResult, len(targets))
+
+	operationsStepper := synchronizedStepper{
+		stepper: stepper,
+		mutex:   &sync.Mutex{},
+	}
+
+	configMerger := &envConfigMerger{
+		mutex:        &sync.Mutex{},
+		elevenConfig: elevenConfig,
+		batchWrites:  options.BatchConfigWrites,
+	}
+
+	// Configs are cloned before running any operation
+	// given that merges modify the passed config
+	operationConfigs := make([]*entities.Config, len(targets))
+	operationConfigsJSONWithoutEnv := make([][]byte, len(targets))
+
+	for targetIndex, target := range targets {
+		results[targetIndex] = EnvOperationResult{
+			ClusterName: target.Cluster.Name,
+			EnvName:     target.Env.Name,
+		}
+
+		operationConfig, err := elevenConfig.Clone()
+
+		if err != nil {
+			results[targetIndex].Error = err
+			continue
+		}
+
+		configJSONWithoutEnv, err := buildConfigJSONWithoutEnv(
+			operationConfig,
+			target.Cluster.Name,
+			target.Env.Name,
+		)
+
+		if err != nil {
+			results[targetIndex].Error = err
+			continue
+		}
+
+		operationConfigs[targetIndex] = operationConfig
+		operationConfigsJSONWithoutEnv[targetIndex] = configJSONWithoutEnv
+	}
+
+	semaphore := make(chan struct{}, maxConcurrency)
+	var operationsWaiter sync.WaitGroup
+
+	for targetIndex, operationConfig := range operationConfigs {
+		if operationConfig == nil {
+			continue
+		}
+
+		operationsWaiter.Add(1)
+
+		go func(targetIndex int, operationConfig *entities.Config) {
+			defer operationsWaiter.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := &results[targetIndex]
+
+			cluster, err := operationConfig.GetCluster(result.ClusterName)
+
+			if err != nil {
+				result.Error = err
+				return
+			}
+
+			env, err := operationConfig.GetEnv(cluster.Name, result.EnvName)
+
+			if err != nil {
+				result.Error = err
+				return
+			}
+
+			result.Error = operation(
+				operationsStepper,
+				envOperationCloudService{
+					CloudService:         cloudService,
+					configMerger:         configMerger,
+					clusterName:          cluster.Name,
+					envName:              env.Name,
+					configJSONWithoutEnv: operationConfigsJSONWithoutEnv[targetIndex],
+				},
+				operationConfig,
+				cluster,
+				env,
+			)
+		}(targetIndex, operationConfig)
+	}
+
+	operationsWaiter.Wait()
+
+	if !configMerger.hasPendingWrite {
+		return results, nil
+	}
+
+	return results, cloudService.SaveElevenConfig(
+		stepper,
+		elevenConfig,
+	)
+}
+
+type envConfigMerger struct {
+	mutex           *sync.Mutex
+	elevenConfig    *entities.Config
+	batchWrites     bool
+	hasPendingWrite bool
+}
+
+// merge copies the state of the env from the operation config
+// to the merged config and saves it. The passed JSON is the
+// operation config, without the env, before the operation ran.
+func (m *envConfigMerger) merge(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	operationConfig *entities.Config,
+	clusterName string,
+	envName string,
+	configJSONWithoutEnv []byte,
+) error {
+
+	updatedConfigJSONWithoutEnv, err := buildConfigJSONWithoutEnv(
+		operationConfig,
+		clusterName,
+		envName,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Only the env is copied so other
+	// changes would be silently lost
+	if !bytes.Equal(updatedConfigJSONWithoutEnv, configJSONWithoutEnv) {
+		return entities.ErrEnvOperationOutOfScope{
+			ClusterName: clusterName,
+			EnvName:     envName,
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !operationConfig.EnvExists(clusterName, envName) {
+		if m.elevenConfig.EnvExists(clusterName, envName) {
+			err := m.elevenConfig.RemoveEnv(clusterName, envName)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return m.save(stepper, cloudService)
+	}
+
+	env, err := operationConfig.GetEnv(clusterName, envName)
+
+	if err != nil {
+		return err
+	}
+
+	// The env is copied given that the
+	// operation may continue to modify it
+	mergedEnv, err := env.Clone()
+
+	if err != nil {
+		return err
+	}
+
+	err = m.elevenConfig.SetEnv(clusterName, mergedEnv)
+
+	if err != nil {
+		return err
+	}
+
+	return m.save(stepper, cloudService)
+}
+
+func (m *envConfigMerger) save(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+) error {
+
+	if m.batchWrites {
+		m.hasPendingWrite = true
+		return nil
+	}
+
+	return cloudService.SaveElevenConfig(stepper, m.elevenConfig)
+}
+
+// buildConfigJSONWithoutEnv returns the JSON of the passed config
+// without the passed env. Used to detect the changes made
+// by the operations outside of their env.
+func buildConfigJSONWithoutEnv(
+	elevenConfig *entities.Config,
+	clusterName string,
+	envName string,
+) ([]byte, error) {
+
+	clonedConfig, err := elevenConfig.Clone()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if clonedConfig.EnvExists(clusterName, envName) {
+		err = clonedConfig.RemoveEnv(clusterName, envName)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(clonedConfig)
+}
+
+// envOperationCloudService merges the
+// config instead of saving it directly
+type envOperationCloudService struct {
+	entities.CloudService
+	configMerger         *envConfigMerger
+	clusterName          string
+	envName              string
+	configJSONWithoutEnv []byte
+}
+
+func (e envOperationCloudService) Unwrap() entities.CloudService {
+	return e.CloudService
+}
+
+func (e envOperationCloudService) SaveElevenConfig(
+	stepper stepper.Stepper,
+	elevenConfig *entities.Config,
+) error {
+
+	return e.configMerger.merge(
+		stepper,
+		e.CloudService,
+		elevenConfig,
+		e.clusterName,
+		e.envName,
+		e.configJSONWithoutEnv,
+	)
+}
+
+// synchronizedStepper serializes the calls made
+// by the concurrent operations to a shared stepper
+type synchronizedStepper struct {
+	stepper stepper.Stepper
+	mutex   *sync.Mutex
+}
+
+func (s synchronizedStepper) StartStep(step string) stepper.Step {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return synchronizedStep{
+		step:  s.stepper.StartStep(step),
+		mutex: s.mutex,
+	}
+}
+
+func (s synchronizedStepper) StartTemporaryStep(step string) stepper.Step {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return synchronizedStep{
+		step:  s.stepper.StartTemporaryStep(step),
+		mutex: s.mutex,
+	}
+}
+
+func (s synchronizedStepper) StartTemporaryStepWithoutNewLine(step string) stepper.Step {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return synchronizedStep{
+		step:  s.stepper.StartTemporaryStepWithoutNewLine(step),
+		mutex: s.mutex,
+	}
+}
+
+func (s synchronizedStepper) StopCurrentStep() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stepper.StopCurrentStep()
+}
+
+type synchronizedStep struct {
+	step  stepper.Step
+	mutex *sync.Mutex
+}
+
+func (s synchronizedStep) Done() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.step.Done()
+}
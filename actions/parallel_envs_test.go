@@ -0,0 +1,370 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type fakeStep struct{}
+
+func (fakeStep) Done() {}
+
+// concurrencyDetectingStepper fails when
+// its methods are called concurrently
+type concurrencyDetectingStepper struct {
+	nbOfCallsInProgress *int32
+	concurrentCalls     *int32
+}
+
+func (c concurrencyDetectingStepper) call() stepper.Step {
+	if atomic.AddInt32(c.nbOfCallsInProgress, 1) > 1 {
+		atomic.AddInt32(c.concurrentCalls, 1)
+	}
+
+	time.Sleep(time.Millisecond)
+	atomic.AddInt32(c.nbOfCallsInProgress, -1)
+
+	return fakeStep{}
+}
+
+func (c concurrencyDetectingStepper) StartStep(string) stepper.Step {
+	return c.call()
+}
+
+func (c concurrencyDetectingStepper) StartTemporaryStep(string) stepper.Step {
+	return c.call()
+}
+
+func (c concurrencyDetectingStepper) StartTemporaryStepWithoutNewLine(string) stepper.Step {
+	return c.call()
+}
+
+func (c concurrencyDetectingStepper) StopCurrentStep() {
+	c.call()
+}
+
+type fakeCloudService struct {
+	entities.CloudService
+	mutex       *sync.Mutex
+	nbOfSaves   int
+	savedConfig *entities.Config
+}
+
+func (f *fakeCloudService) SaveElevenConfig(
+	stepper stepper.Stepper,
+	elevenConfig *entities.Config,
+) error {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.nbOfSaves++
+	f.savedConfig = elevenConfig
+
+	return nil
+}
+
+func buildTestParallelConfig(t *testing.T, nbOfEnvs int) (*entities.Config, []EnvTarget) {
+	elevenConfig := entities.NewConfig()
+	cluster := entities.NewCluster(entities.DefaultClusterName, "t2.medium", true)
+	elevenConfig.Clusters[cluster.Name] = cluster
+
+	targets := []EnvTarget{}
+
+	for envIndex := 0; envIndex < nbOfEnvs; envIndex++ {
+		env := &entities.Env{
+			Name:   fmt.Sprintf("env%d", envIndex),
+			Status: entities.EnvStatusCreated,
+		}
+
+		err := elevenConfig.SetEnv(cluster.Name, env)
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		targets = append(targets, EnvTarget{
+			Cluster: cluster,
+			Env:     env,
+		})
+	}
+
+	return elevenConfig, targets
+}
+
+func TestRunEnvOperationsInParallel(t *testing.T) {
+	operationErr := errors.New("operation error")
+
+	testCases := []struct {
+		test              string
+		batchConfigWrites bool
+		failingEnvName    string
+		expectedNbOfSaves int
+	}{
+		{
+			test:              "with successful operations",
+			expectedNbOfSaves: 4,
+		},
+
+		{
+			test:              "with batched config writes",
+			batchConfigWrites: true,
+			expectedNbOfSaves: 1,
+		},
+
+		{
+			test:              "with partial failure",
+			failingEnvName:    "env2",
+			expectedNbOfSaves: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			elevenConfig, targets := buildTestParallelConfig(t, 4)
+			cloudService := &fakeCloudService{mutex: &sync.Mutex{}}
+
+			results, err := RunEnvOperationsInParallel(
+				nil,
+				cloudService,
+				elevenConfig,
+				targets,
+				EnvOperationsOptions{
+					MaxConcurrency:    2,
+					BatchConfigWrites: tc.batchConfigWrites,
+				},
+				func(
+					stepper stepper.Stepper,
+					cloudService entities.CloudService,
+					elevenConfig *entities.Config,
+					cluster *entities.Cluster,
+					env *entities.Env,
+				) error {
+
+					if env.Name == tc.failingEnvName {
+						return operationErr
+					}
+
+					return stopEnvInConfigForTest(cloudService, elevenConfig, cluster, env)
+				},
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if cloudService.nbOfSaves != tc.expectedNbOfSaves {
+				t.Fatalf(
+					"expected number of saves to equal '%d', got '%d'",
+					tc.expectedNbOfSaves,
+					cloudService.nbOfSaves,
+				)
+			}
+
+			for resultIndex, result := range results {
+				// Results are returned in the same order than the targets
+				if result.EnvName != targets[resultIndex].Env.Name {
+					t.Fatalf(
+						"expected result env name to equal '%s', got '%s'",
+						targets[resultIndex].Env.Name,
+						result.EnvName,
+					)
+				}
+
+				expectedStatus := entities.EnvStatusStopped
+				var expectedError error
+
+				if result.EnvName == tc.failingEnvName {
+					expectedStatus = entities.EnvStatusCreated
+					expectedError = operationErr
+				}
+
+				if !errors.Is(result.Error, expectedError) {
+					t.Fatalf("expected error to equal '%+v', got '%+v'", expectedError, result.Error)
+				}
+
+				// Each operation's env is merged in the passed config
+				mergedEnv, err := elevenConfig.GetEnv(result.ClusterName, result.EnvName)
+
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				if mergedEnv.Status != expectedStatus {
+					t.Fatalf(
+						"expected status of '%s' to equal '%s', got '%s'",
+						result.EnvName,
+						expectedStatus,
+						mergedEnv.Status,
+					)
+				}
+			}
+
+			if cloudService.savedConfig != elevenConfig {
+				t.Fatalf("expected the passed config to be saved")
+			}
+		})
+	}
+}
+
+func stopEnvInConfigForTest(
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	env.Status = entities.EnvStatusStopped
+
+	return UpdateEnvInConfig(
+		nil,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+}
+
+func TestRunEnvOperationsInParallelWithRemovedEnv(t *testing.T) {
+	elevenConfig, targets := buildTestParallelConfig(t, 2)
+	cloudService := &fakeCloudService{mutex: &sync.Mutex{}}
+
+	results, err := RunEnvOperationsInParallel(
+		nil,
+		cloudService,
+		elevenConfig,
+		targets,
+		EnvOperationsOptions{},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			return RemoveEnvInConfig(nil, cloudService, elevenConfig, cluster, env)
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	for _, result := range results {
+		if result.Error != nil {
+			t.Fatalf("expected no error, got '%+v'", result.Error)
+		}
+	}
+
+	nbOfEnvs := len(elevenConfig.Clusters[entities.DefaultClusterName].Envs)
+
+	if nbOfEnvs != 0 {
+		t.Fatalf("expected all envs to be removed, got '%d' envs", nbOfEnvs)
+	}
+}
+
+func TestRunEnvOperationsInParallelWithChangesOutsideEnv(t *testing.T) {
+	elevenConfig, targets := buildTestParallelConfig(t, 2)
+	cloudService := &fakeCloudService{mutex: &sync.Mutex{}}
+
+	results, err := RunEnvOperationsInParallel(
+		nil,
+		cloudService,
+		elevenConfig,
+		targets,
+		EnvOperationsOptions{},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			if env.Name == "env0" {
+				cluster.Labels = entities.Labels{"team": "backend"}
+			}
+
+			return stopEnvInConfigForTest(cloudService, elevenConfig, cluster, env)
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedError := entities.ErrEnvOperationOutOfScope{
+		ClusterName: entities.DefaultClusterName,
+		EnvName:     "env0",
+	}
+
+	if results[0].Error != expectedError {
+		t.Fatalf("expected error to equal '%+v', got '%+v'", expectedError, results[0].Error)
+	}
+
+	if results[1].Error != nil {
+		t.Fatalf("expected no error, got '%+v'", results[1].Error)
+	}
+
+	cluster := elevenConfig.Clusters[entities.DefaultClusterName]
+
+	if len(cluster.Labels) != 0 {
+		t.Fatalf("expected cluster labels to be left untouched, got '%+v'", cluster.Labels)
+	}
+
+	if cluster.Envs["env0"].Status != entities.EnvStatusCreated {
+		t.Fatalf("expected rejected env to be left untouched, got '%s'", cluster.Envs["env0"].Status)
+	}
+
+	if cluster.Envs["env1"].Status != entities.EnvStatusStopped {
+		t.Fatalf("expected env1 to be merged, got '%s'", cluster.Envs["env1"].Status)
+	}
+}
+
+func TestRunEnvOperationsInParallelSerializesStepperCalls(t *testing.T) {
+	elevenConfig, targets := buildTestParallelConfig(t, 8)
+	cloudService := &fakeCloudService{mutex: &sync.Mutex{}}
+
+	var nbOfCallsInProgress, concurrentCalls int32
+
+	_, err := RunEnvOperationsInParallel(
+		concurrencyDetectingStepper{
+			nbOfCallsInProgress: &nbOfCallsInProgress,
+			concurrentCalls:     &concurrentCalls,
+		},
+		cloudService,
+		elevenConfig,
+		targets,
+		EnvOperationsOptions{MaxConcurrency: 8},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			for i := 0; i < 5; i++ {
+				stepper.StartTemporaryStep(env.Name).Done()
+			}
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if concurrentCalls != 0 {
+		t.Fatalf("expected no concurrent stepper calls, got '%d'", concurrentCalls)
+	}
+}
@@ -12,7 +12,7 @@ func RemoveCluster(
 	cluster *entities.Cluster,
 ) error {
 
-	cluster.Status = entities.ClusterStatusRemoving
+	cluster.SetStatus(entities.ClusterStatusRemoving)
 	err := UpdateClusterInConfig(
 		stepper,
 		cloudService,
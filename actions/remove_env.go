@@ -14,7 +14,7 @@ func RemoveEnv(
 	preRemoveHook entities.HookRunner,
 ) error {
 
-	env.Status = entities.EnvStatusRemoving
+	env.SetStatus(entities.EnvStatusRemoving)
 	err := UpdateEnvInConfig(
 		stepper,
 		cloudService,
@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func ResizeEnv(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	instanceType string,
+) error {
+
+	resizeEnvErr := cloudService.ResizeEnv(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+		instanceType,
+	)
+
+	// "resizeEnvErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return resizeEnvErr
+}
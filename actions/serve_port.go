@@ -0,0 +1,191 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// ServePort serves the passed port using the passed binding
+// (a port or a domain) and returns the binding used.
+func ServePort(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	port string,
+	portBinding string,
+	domainReachabilityChecker entities.DomainReachabilityChecker,
+) (string, error) {
+
+	if env.Status == entities.EnvStatusRemoving {
+		return "", entities.ErrServeRemovingEnv{
+			EnvName: env.Name,
+		}
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return "", entities.ErrServeCreatingEnv{
+			EnvName: env.Name,
+		}
+	}
+
+	if len(portBinding) == 0 {
+		// if no binding was passed,
+		// the user wants port to be served
+		// using same port number
+		portBinding = port
+	}
+
+	portBindingAlreadyUsed := env.DoesServedPortBindingExist(portBinding)
+
+	if !portBindingAlreadyUsed && govalidator.IsPort(portBinding) {
+		err := OpenPort(
+			stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+			portBinding,
+		)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	redirPortBindingHTTPS := false
+
+	if !govalidator.IsPort(portBinding) {
+		step := fmt.Sprintf(
+			"Checking that \"%s\" resolves to your sandbox's public IP address",
+			portBinding,
+		)
+
+		stepper.StartTemporaryStep(step)
+
+		reachable, redirToHTTPS, err := domainReachabilityChecker.Check(
+			env,
+			portBinding,
+		)
+
+		if err != nil {
+			return "", err
+		}
+
+		if !reachable {
+			return "", entities.ErrUnresolvableDomain{
+				Domain:       portBinding,
+				EnvIPAddress: env.InstancePublicIPAddress,
+			}
+		}
+
+		redirPortBindingHTTPS = redirToHTTPS
+	}
+
+	if portBindingAlreadyUsed {
+		env.RemoveServedPortBinding(
+			entities.EnvServedPort(port),
+			portBinding,
+		)
+	}
+
+	env.AddServedPortBinding(
+		entities.EnvServedPort(port),
+		portBinding,
+		redirPortBindingHTTPS,
+	)
+
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return portBinding, nil
+}
+
+func UnservePort(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	port string,
+) error {
+
+	if env.Status == entities.EnvStatusRemoving {
+		return entities.ErrUnserveRemovingEnv{
+			EnvName: env.Name,
+		}
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return entities.ErrUnserveCreatingEnv{
+			EnvName: env.Name,
+		}
+	}
+
+	portAlreadyUnserved := !env.DoesServedPortExist(
+		entities.EnvServedPort(port),
+	)
+
+	if portAlreadyUnserved {
+		return nil
+	}
+
+	for _, binding := range env.ServedPorts[entities.EnvServedPort(port)] {
+		if binding.Type != entities.EnvServedPortBindingTypePort {
+			continue
+		}
+
+		err := ClosePort(
+			stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+			binding.Value,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		env.RemoveServedPortBinding(
+			entities.EnvServedPort(port),
+			binding.Value,
+		)
+
+		err = UpdateEnvInConfig(
+			stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	env.RemoveServedPort(entities.EnvServedPort(port))
+
+	return UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+}
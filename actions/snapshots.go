@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func CreateSnapshot(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	snapshot *entities.Snapshot,
+) error {
+
+	cluster.SetSnapshot(snapshot)
+
+	createSnapshotErr := cloudService.CreateSnapshot(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+		snapshot,
+	)
+
+	// "createSnapshotErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if createSnapshotErr != nil {
+		return createSnapshotErr
+	}
+
+	snapshot.Status = entities.SnapshotStatusCreated
+	return UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+}
+
+func RemoveSnapshot(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	snapshot *entities.Snapshot,
+) error {
+
+	snapshot.Status = entities.SnapshotStatusRemoving
+	err := UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	removeSnapshotErr := cloudService.RemoveSnapshot(
+		stepper,
+		elevenConfig,
+		cluster,
+		snapshot,
+	)
+
+	// "removeSnapshotErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err = UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if removeSnapshotErr != nil {
+		return removeSnapshotErr
+	}
+
+	err = cluster.RemoveSnapshot(snapshot.Name)
+
+	if err != nil {
+		return err
+	}
+
+	return UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+}
+
+// RestoreSnapshot is the equivalent of CreateEnv
+// for envs created from a snapshot
+func RestoreSnapshot(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	snapshot *entities.Snapshot,
+) error {
+
+	restoreSnapshotErr := cloudService.RestoreSnapshot(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+		snapshot,
+	)
+
+	// "restoreSnapshotErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return restoreSnapshotErr
+}
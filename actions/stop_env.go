@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func StopEnv(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	stopEnvErr := cloudService.StopEnv(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if stopEnvErr == nil {
+		env.SetStatus(entities.EnvStatusStopped)
+	}
+
+	// "stopEnvErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return stopEnvErr
+}
+
+func StartEnv(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	startEnvErr := cloudService.StartEnv(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if startEnvErr == nil {
+		env.SetStatus(entities.EnvStatusCreated)
+		env.RenewExpiry(time.Now())
+	}
+
+	// "startEnvErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return startEnvErr
+}
@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"errors"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// CreateEnvFromTemporarySnapshot creates the target env from a
+// snapshot of the source env that is removed once used.
+// The snapshot is stored in the source env's cluster and
+// is reused when a previous call failed.
+func CreateEnvFromTemporarySnapshot(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	sourceCluster *entities.Cluster,
+	sourceEnv *entities.Env,
+	targetCluster *entities.Cluster,
+	targetEnv *entities.Env,
+	snapshotName string,
+) error {
+
+	snapshot, err := sourceCluster.GetSnapshot(snapshotName)
+
+	if err != nil && !errors.As(err, &entities.ErrSnapshotNotExists{}) {
+		return err
+	}
+
+	if snapshot != nil && snapshot.SourceEnvName != sourceEnv.Name {
+		return entities.ErrSnapshotAlreadyExists{
+			ClusterName:  sourceCluster.Name,
+			SnapshotName: snapshot.Name,
+		}
+	}
+
+	if snapshot == nil {
+		snapshot = entities.NewSnapshot(snapshotName, sourceEnv)
+	}
+
+	// Snapshots still in creating state
+	// after error could be created again
+	if snapshot.Status == entities.SnapshotStatusCreating {
+		err = CreateSnapshot(
+			stepper,
+			cloudService,
+			elevenConfig,
+			sourceCluster,
+			sourceEnv,
+			snapshot,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Snapshots in removing state were
+	// already restored by a previous call
+	if snapshot.Status == entities.SnapshotStatusCreated {
+		err = RestoreSnapshot(
+			stepper,
+			cloudService,
+			elevenConfig,
+			targetCluster,
+			targetEnv,
+			snapshot,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return RemoveSnapshot(
+		stepper,
+		cloudService,
+		elevenConfig,
+		sourceCluster,
+		snapshot,
+	)
+}
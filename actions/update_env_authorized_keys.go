@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func UpdateEnvAuthorizedKeys(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	updateAuthorizedKeysErr := cloudService.UpdateEnvAuthorizedKeys(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	// "updateAuthorizedKeysErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return updateAuthorizedKeysErr
+}
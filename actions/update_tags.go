@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+func UpdateEnvTags(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	updateTagsErr := cloudService.UpdateEnvTags(
+		stepper,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	// "updateTagsErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return updateTagsErr
+}
+
+func UpdateClusterTags(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+) error {
+
+	updateTagsErr := cloudService.UpdateClusterTags(
+		stepper,
+		elevenConfig,
+		cluster,
+	)
+
+	// "updateTagsErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateClusterInConfig(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return updateTagsErr
+}
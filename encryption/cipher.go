@@ -0,0 +1,74 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+)
+
+const dataKeyLength = 32 // AES-256
+
+func generateDataKey() ([]byte, error) {
+	dataKey := make([]byte, dataKeyLength)
+
+	_, err := io.ReadFull(rand.Reader, dataKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dataKey, nil
+}
+
+// seal encrypts the passed plaintext using AES-GCM.
+// The returned ciphertext is prefixed with the random nonce.
+func seal(key []byte, plaintext []byte) ([]byte, error) {
+	aead, err := buildAEAD(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = io.ReadFull(rand.Reader, nonce)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key []byte, ciphertext []byte) ([]byte, error) {
+	aead, err := buildAEAD(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrInvalidEncryptedValue
+	}
+
+	nonce := ciphertext[:aead.NonceSize()]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext[aead.NonceSize():], nil)
+
+	if err != nil {
+		return nil, ErrInvalidEncryptedValue
+	}
+
+	return plaintext, nil
+}
+
+func buildAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
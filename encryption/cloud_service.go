@@ -0,0 +1,152 @@
+package encryption
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// CloudService wraps a cloud service to transparently
+// decrypt the Eleven config on lookup and encrypt it on save.
+type CloudService struct {
+	entities.CloudService
+	configEncrypter *ConfigEncrypter
+}
+
+func NewCloudService(
+	cloudService entities.CloudService,
+	keyProvider KeyProvider,
+) CloudService {
+
+	return CloudService{
+		CloudService:    cloudService,
+		configEncrypter: NewConfigEncrypter(keyProvider),
+	}
+}
+
+func (c CloudService) Unwrap() entities.CloudService {
+	return c.CloudService
+}
+
+func (c CloudService) LookupElevenConfig(
+	stepper stepper.Stepper,
+) (*entities.Config, error) {
+
+	elevenConfig, err := c.CloudService.LookupElevenConfig(stepper)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.configEncrypter.Decrypt(elevenConfig)
+
+	if err != nil {
+		return nil, ErrConfigDecryptionFailed{
+			ReturnedError: err,
+		}
+	}
+
+	return elevenConfig, nil
+}
+
+// SaveElevenConfig saves an encrypted copy of the passed config.
+// The passed config is kept decrypted given that it is still
+// used by the caller.
+func (c CloudService) SaveElevenConfig(
+	stepper stepper.Stepper,
+	elevenConfig *entities.Config,
+) error {
+
+	encryptedConfig, err := elevenConfig.Clone()
+
+	if err != nil {
+		return err
+	}
+
+	err = c.configEncrypter.Encrypt(encryptedConfig)
+
+	if err != nil {
+		return err
+	}
+
+	// Keep the generated data key, if any,
+	// to reuse it during the next saves
+	elevenConfig.Encryption = encryptedConfig.Encryption
+
+	return c.CloudService.SaveElevenConfig(
+		stepper,
+		encryptedConfig,
+	)
+}
+
+type KeyProviderBuilder func(cloudService entities.CloudService) (KeyProvider, error)
+
+// CloudServiceBuilder wraps a cloud service builder
+// to return cloud services that encrypt the Eleven config.
+type CloudServiceBuilder struct {
+	cloudServiceBuilder entities.CloudServiceBuilder
+	keyProviderBuilder  KeyProviderBuilder
+}
+
+func NewCloudServiceBuilder(
+	cloudServiceBuilder entities.CloudServiceBuilder,
+	keyProviderBuilder KeyProviderBuilder,
+) CloudServiceBuilder {
+
+	return CloudServiceBuilder{
+		cloudServiceBuilder: cloudServiceBuilder,
+		keyProviderBuilder:  keyProviderBuilder,
+	}
+}
+
+func (c CloudServiceBuilder) Build() (entities.CloudService, error) {
+	cloudService, err := c.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyProvider, err := c.keyProviderBuilder(cloudService)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCloudService(cloudService, keyProvider), nil
+}
+
+// MigrateConfig encrypts the plaintext sensitive fields of
+// a config saved before encryption was enabled. The passed cloud
+// service must be the unwrapped one. The number of encrypted
+// fields is returned.
+func MigrateConfig(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	keyProvider KeyProvider,
+) (int, error) {
+
+	elevenConfig, err := cloudService.LookupElevenConfig(stepper)
+
+	if err != nil {
+		return 0, err
+	}
+
+	nbOfPlaintextFields := CountPlaintextSensitiveFields(elevenConfig)
+
+	if nbOfPlaintextFields == 0 {
+		return 0, nil
+	}
+
+	err = NewConfigEncrypter(keyProvider).Encrypt(elevenConfig)
+
+	if err != nil {
+		return 0, err
+	}
+
+	err = cloudService.SaveElevenConfig(stepper, elevenConfig)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return nbOfPlaintextFields, nil
+}
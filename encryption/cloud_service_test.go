@@ -0,0 +1,303 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type fakeCloudService struct {
+	entities.CloudService
+	savedConfigJSON []byte
+}
+
+func (f *fakeCloudService) LookupElevenConfig(
+	stepper.Stepper,
+) (*entities.Config, error) {
+
+	if f.savedConfigJSON == nil {
+		return nil, entities.ErrElevenNotInstalled
+	}
+
+	var config *entities.Config
+	err := json.Unmarshal(f.savedConfigJSON, &config)
+
+	return config, err
+}
+
+func (f *fakeCloudService) SaveElevenConfig(
+	stepper stepper.Stepper,
+	config *entities.Config,
+) error {
+
+	configJSON, err := json.Marshal(config)
+
+	if err != nil {
+		return err
+	}
+
+	f.savedConfigJSON = configJSON
+
+	return nil
+}
+
+type fakeKMSCloudService struct {
+	fakeCloudService
+}
+
+func (fakeKMSCloudService) EncryptDataKey(
+	stepper stepper.Stepper,
+	dataKey []byte,
+) ([]byte, error) {
+
+	return append([]byte("kms:"), dataKey...), nil
+}
+
+func (fakeKMSCloudService) DecryptDataKey(
+	stepper stepper.Stepper,
+	encryptedDataKey []byte,
+) ([]byte, error) {
+
+	return bytes.TrimPrefix(encryptedDataKey, []byte("kms:")), nil
+}
+
+func buildTestConfig(t *testing.T, sshKeyPairPEMContent string) *entities.Config {
+	config := entities.NewConfig()
+	cluster := entities.NewCluster("cluster_name", "instance_type", true)
+	env := entities.NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]entities.EnvRepository{},
+		entities.EnvRuntimes{},
+	)
+
+	env.SSHKeyPairPEMContent = sshKeyPairPEMContent
+
+	if err := config.SetCluster(cluster); err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if err := config.SetEnv(cluster.Name, env); err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	return config
+}
+
+func TestCloudServiceEncryptsConfig(t *testing.T) {
+	fakeService := &fakeCloudService{}
+	cloudService := NewCloudService(fakeService, &fakeKMSCloudServiceKeyProvider{})
+
+	config := buildTestConfig(t, "private_key_content")
+
+	err := cloudService.SaveElevenConfig(nil, config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if strings.Contains(string(fakeService.savedConfigJSON), "private_key_content") {
+		t.Fatalf("expected saved config to not contain plaintext secrets")
+	}
+
+	env := config.Clusters["cluster_name"].Envs["env_name"]
+
+	if env.SSHKeyPairPEMContent != "private_key_content" {
+		t.Fatalf("expected passed config to stay decrypted")
+	}
+
+	if config.Encryption == nil {
+		t.Fatalf("expected passed config to keep the data key")
+	}
+
+	lookedUpConfig, err := cloudService.LookupElevenConfig(nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	lookedUpEnv := lookedUpConfig.Clusters["cluster_name"].Envs["env_name"]
+
+	if lookedUpEnv.SSHKeyPairPEMContent != "private_key_content" {
+		t.Fatalf(
+			"expected decrypted SSH key to equal '%s', got '%s'",
+			"private_key_content",
+			lookedUpEnv.SSHKeyPairPEMContent,
+		)
+	}
+
+	// The data key must be reused between saves
+	encryptedDataKey := config.Encryption.EncryptedDataKey
+
+	err = cloudService.SaveElevenConfig(nil, lookedUpConfig)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if lookedUpConfig.Encryption.EncryptedDataKey != encryptedDataKey {
+		t.Fatalf("expected data key to be reused")
+	}
+}
+
+func TestCloudServiceWithKeyProviderMismatch(t *testing.T) {
+	fakeService := &fakeCloudService{}
+
+	err := NewCloudService(
+		fakeService,
+		&fakeKMSCloudServiceKeyProvider{},
+	).SaveElevenConfig(nil, buildTestConfig(t, "private_key_content"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	_, err = NewCloudService(
+		fakeService,
+		NewPassphraseKeyProvider("passphrase"),
+	).LookupElevenConfig(nil)
+
+	if err == nil || !errors.As(err, &ErrKeyProviderMismatch{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrKeyProviderMismatch{},
+			err,
+		)
+	}
+	// Reported as an encryption problem by the doctor
+	if !errors.As(err, &ErrConfigDecryptionFailed{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrConfigDecryptionFailed{},
+			err,
+		)
+	}
+}
+
+func TestCloudServiceWithPlaintextConfig(t *testing.T) {
+	fakeService := &fakeCloudService{}
+
+	err := fakeService.SaveElevenConfig(nil, buildTestConfig(t, "private_key_content"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	cloudService := NewCloudService(fakeService, &fakeKMSCloudServiceKeyProvider{})
+
+	config, err := cloudService.LookupElevenConfig(nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	env := config.Clusters["cluster_name"].Envs["env_name"]
+
+	if env.SSHKeyPairPEMContent != "private_key_content" {
+		t.Fatalf("expected plaintext SSH key to be returned as is")
+	}
+}
+
+type fakeConnectivityCheckerCloudService struct {
+	fakeCloudService
+	connectivityErr error
+}
+
+func (f *fakeConnectivityCheckerCloudService) CheckConnectivity(
+	stepper.Stepper,
+) error {
+
+	return f.connectivityErr
+}
+
+func TestCloudServiceExposesWrappedConnectivityChecker(t *testing.T) {
+	connectivityErr := errors.New("connectivity_error")
+	fakeService := &fakeConnectivityCheckerCloudService{
+		connectivityErr: connectivityErr,
+	}
+
+	cloudService := NewCloudService(
+		fakeService,
+		fakeKMSCloudServiceKeyProvider{},
+	)
+
+	connectivityChecker, ok := entities.LookupCloudServiceConnectivityChecker(
+		cloudService,
+	)
+
+	if !ok {
+		t.Fatalf("expected the wrapped connectivity checker to be found")
+	}
+
+	err := connectivityChecker.CheckConnectivity(nil)
+
+	if !errors.Is(err, connectivityErr) {
+		t.Fatalf("expected error to equal '%+v', got '%+v'", connectivityErr, err)
+	}
+
+	_, ok = entities.LookupCloudServiceConnectivityChecker(
+		NewCloudService(&fakeCloudService{}, fakeKMSCloudServiceKeyProvider{}),
+	)
+
+	if ok {
+		t.Fatalf("expected no connectivity checker to be found")
+	}
+}
+
+func TestMigrateConfig(t *testing.T) {
+	fakeService := &fakeCloudService{}
+
+	err := fakeService.SaveElevenConfig(nil, buildTestConfig(t, "private_key_content"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	keyProvider := &fakeKMSCloudServiceKeyProvider{}
+
+	nbOfMigratedFields, err := MigrateConfig(nil, fakeService, keyProvider)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if nbOfMigratedFields != 1 {
+		t.Fatalf("expected 1 migrated field, got %d", nbOfMigratedFields)
+	}
+
+	if strings.Contains(string(fakeService.savedConfigJSON), "private_key_content") {
+		t.Fatalf("expected saved config to not contain plaintext secrets")
+	}
+
+	nbOfMigratedFields, err = MigrateConfig(nil, fakeService, keyProvider)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if nbOfMigratedFields != 0 {
+		t.Fatalf("expected no migrated field, got %d", nbOfMigratedFields)
+	}
+}
+
+// fakeKMSCloudServiceKeyProvider is a fast key provider
+// (scrypt is intentionally slow) used in tests
+type fakeKMSCloudServiceKeyProvider struct{}
+
+func (fakeKMSCloudServiceKeyProvider) ID() string {
+	return "fake"
+}
+
+func (fakeKMSCloudServiceKeyProvider) EncryptDataKey(dataKey []byte) ([]byte, error) {
+	return fakeKMSCloudService{}.EncryptDataKey(nil, dataKey)
+}
+
+func (fakeKMSCloudServiceKeyProvider) DecryptDataKey(encryptedDataKey []byte) ([]byte, error) {
+	return fakeKMSCloudService{}.DecryptDataKey(nil, encryptedDataKey)
+}
@@ -0,0 +1,175 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"github.com/eleven-sh/eleven/entities"
+)
+
+const encryptedValuePrefix = entities.EncryptedSensitiveFieldPrefix
+
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// ConfigEncrypter encrypts and decrypts the sensitive fields
+// of an Eleven config. The decrypted data key is cached to avoid
+// calling the key provider (scrypt, KMS...) on each operation.
+type ConfigEncrypter struct {
+	keyProvider KeyProvider
+
+	mutex            *sync.Mutex
+	dataKey          []byte
+	encryptedDataKey string
+}
+
+func NewConfigEncrypter(keyProvider KeyProvider) *ConfigEncrypter {
+	return &ConfigEncrypter{
+		keyProvider: keyProvider,
+		mutex:       &sync.Mutex{},
+	}
+}
+
+// Encrypt encrypts, in place, all the plaintext sensitive fields
+// of the passed config. A data key is generated if the config
+// doesn't have one yet.
+func (c *ConfigEncrypter) Encrypt(config *entities.Config) error {
+	dataKey, err := c.resolveDataKey(config)
+
+	if err != nil {
+		return err
+	}
+
+	for _, field := range config.SensitiveFields() {
+		if len(*field) == 0 || IsEncryptedValue(*field) {
+			continue
+		}
+
+		encryptedValue, err := seal(dataKey, []byte(*field))
+
+		if err != nil {
+			return err
+		}
+
+		*field = encryptedValuePrefix +
+			base64.StdEncoding.EncodeToString(encryptedValue)
+	}
+
+	return nil
+}
+
+// Decrypt decrypts, in place, all the encrypted sensitive fields of
+// the passed config. Plaintext fields (from configs saved before
+// encryption was enabled) are left untouched.
+func (c *ConfigEncrypter) Decrypt(config *entities.Config) error {
+	for _, field := range config.SensitiveFields() {
+		if !IsEncryptedValue(*field) {
+			continue
+		}
+
+		if config.Encryption == nil {
+			return ErrMissingDataKey
+		}
+
+		dataKey, err := c.resolveDataKey(config)
+
+		if err != nil {
+			return err
+		}
+
+		encryptedValue, err := base64.StdEncoding.DecodeString(
+			strings.TrimPrefix(*field, encryptedValuePrefix),
+		)
+
+		if err != nil {
+			return ErrInvalidEncryptedValue
+		}
+
+		decryptedValue, err := open(dataKey, encryptedValue)
+
+		if err != nil {
+			return err
+		}
+
+		*field = string(decryptedValue)
+	}
+
+	return nil
+}
+
+func (c *ConfigEncrypter) resolveDataKey(config *entities.Config) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if config.Encryption == nil {
+		dataKey, err := generateDataKey()
+
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedDataKey, err := c.keyProvider.EncryptDataKey(dataKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.Encryption = &entities.ConfigEncryption{
+			KeyProviderID:    c.keyProvider.ID(),
+			EncryptedDataKey: base64.StdEncoding.EncodeToString(encryptedDataKey),
+		}
+
+		c.dataKey = dataKey
+		c.encryptedDataKey = config.Encryption.EncryptedDataKey
+
+		return dataKey, nil
+	}
+
+	if config.Encryption.KeyProviderID != c.keyProvider.ID() {
+		return nil, ErrKeyProviderMismatch{
+			ConfigKeyProviderID: config.Encryption.KeyProviderID,
+			KeyProviderID:       c.keyProvider.ID(),
+		}
+	}
+
+	if c.dataKey != nil &&
+		c.encryptedDataKey == config.Encryption.EncryptedDataKey {
+
+		return c.dataKey, nil
+	}
+
+	encryptedDataKey, err := base64.StdEncoding.DecodeString(
+		config.Encryption.EncryptedDataKey,
+	)
+
+	if err != nil {
+		return nil, ErrInvalidEncryptedValue
+	}
+
+	dataKey, err := c.keyProvider.DecryptDataKey(encryptedDataKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.dataKey = dataKey
+	c.encryptedDataKey = config.Encryption.EncryptedDataKey
+
+	return dataKey, nil
+}
+
+// CountPlaintextSensitiveFields returns the number of non-empty
+// sensitive fields that are not encrypted in the passed config.
+func CountPlaintextSensitiveFields(config *entities.Config) int {
+	count := 0
+
+	for _, field := range config.SensitiveFields() {
+		if len(*field) > 0 && !IsEncryptedValue(*field) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,97 @@
+package encryption
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigEncrypterRoundTrip(t *testing.T) {
+	config := buildTestConfig(t, "private_key_content")
+	env := config.Clusters["cluster_name"].Envs["env_name"]
+
+	encrypter := NewConfigEncrypter(fakeKMSCloudServiceKeyProvider{})
+
+	err := encrypter.Encrypt(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !IsEncryptedValue(env.SSHKeyPairPEMContent) {
+		t.Fatalf("expected SSH key to be encrypted")
+	}
+
+	if len(env.PendingSSHKeyPairPEMContent) > 0 {
+		t.Fatalf("expected empty fields to stay empty")
+	}
+
+	if CountPlaintextSensitiveFields(config) != 0 {
+		t.Fatalf("expected no plaintext sensitive field")
+	}
+
+	// Already encrypted fields must not be encrypted twice
+	encryptedValue := env.SSHKeyPairPEMContent
+
+	err = encrypter.Encrypt(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.SSHKeyPairPEMContent != encryptedValue {
+		t.Fatalf("expected encrypted field to be left untouched")
+	}
+
+	err = NewConfigEncrypter(fakeKMSCloudServiceKeyProvider{}).Decrypt(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.SSHKeyPairPEMContent != "private_key_content" {
+		t.Fatalf(
+			"expected decrypted SSH key to equal '%s', got '%s'",
+			"private_key_content",
+			env.SSHKeyPairPEMContent,
+		)
+	}
+}
+
+func TestConfigEncrypterDecryptWithoutDataKey(t *testing.T) {
+	config := buildTestConfig(t, encryptedValuePrefix+"Zm9v")
+
+	err := NewConfigEncrypter(fakeKMSCloudServiceKeyProvider{}).Decrypt(config)
+
+	if !errors.Is(err, ErrMissingDataKey) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrMissingDataKey,
+			err,
+		)
+	}
+}
+
+func TestConfigEncrypterDecryptWithTamperedValue(t *testing.T) {
+	config := buildTestConfig(t, "private_key_content")
+	env := config.Clusters["cluster_name"].Envs["env_name"]
+
+	encrypter := NewConfigEncrypter(fakeKMSCloudServiceKeyProvider{})
+
+	err := encrypter.Encrypt(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	env.SSHKeyPairPEMContent = env.SSHKeyPairPEMContent[:len(env.SSHKeyPairPEMContent)-4] + "AAAA"
+
+	err = encrypter.Decrypt(config)
+
+	if !errors.Is(err, ErrInvalidEncryptedValue) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidEncryptedValue,
+			err,
+		)
+	}
+}
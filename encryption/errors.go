@@ -0,0 +1,33 @@
+package encryption
+
+import "errors"
+
+var (
+	ErrInvalidEncryptedValue = errors.New("ErrInvalidEncryptedValue")
+	ErrInvalidKeyFile        = errors.New("ErrInvalidKeyFile")
+	ErrMissingDataKey        = errors.New("ErrMissingDataKey")
+	ErrKMSNotSupported       = errors.New("ErrKMSNotSupported")
+)
+
+type ErrKeyProviderMismatch struct {
+	ConfigKeyProviderID string
+	KeyProviderID       string
+}
+
+func (ErrKeyProviderMismatch) Error() string {
+	return "ErrKeyProviderMismatch"
+}
+
+// ErrConfigDecryptionFailed wraps the errors returned
+// while decrypting a looked up config
+type ErrConfigDecryptionFailed struct {
+	ReturnedError error
+}
+
+func (ErrConfigDecryptionFailed) Error() string {
+	return "ErrConfigDecryptionFailed"
+}
+
+func (e ErrConfigDecryptionFailed) Unwrap() error {
+	return e.ReturnedError
+}
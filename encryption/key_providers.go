@@ -0,0 +1,190 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeyProvider encrypts the data key used to encrypt
+// the sensitive fields of the Eleven config (envelope encryption).
+type KeyProvider interface {
+	ID() string
+	EncryptDataKey(dataKey []byte) ([]byte, error)
+	DecryptDataKey(encryptedDataKey []byte) ([]byte, error)
+}
+
+const (
+	PassphraseKeyProviderID = "passphrase"
+	FileKeyProviderID       = "file"
+	CloudKMSKeyProviderID   = "cloud_kms"
+)
+
+// Recommended scrypt parameters for interactive logins (2017)
+const (
+	scryptN       = 32768
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 16
+)
+
+// PassphraseKeyProvider derives a key from a passphrase using scrypt.
+// The random salt is stored alongside the encrypted data key.
+type PassphraseKeyProvider struct {
+	passphrase []byte
+}
+
+func NewPassphraseKeyProvider(passphrase string) PassphraseKeyProvider {
+	return PassphraseKeyProvider{
+		passphrase: []byte(passphrase),
+	}
+}
+
+func (PassphraseKeyProvider) ID() string {
+	return PassphraseKeyProviderID
+}
+
+func (p PassphraseKeyProvider) EncryptDataKey(dataKey []byte) ([]byte, error) {
+	salt := make([]byte, scryptSaltLen)
+
+	_, err := io.ReadFull(rand.Reader, salt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := p.deriveKey(salt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedDataKey, err := seal(key, dataKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(salt, encryptedDataKey...), nil
+}
+
+func (p PassphraseKeyProvider) DecryptDataKey(encryptedDataKey []byte) ([]byte, error) {
+	if len(encryptedDataKey) < scryptSaltLen {
+		return nil, ErrInvalidEncryptedValue
+	}
+
+	key, err := p.deriveKey(encryptedDataKey[:scryptSaltLen])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return open(key, encryptedDataKey[scryptSaltLen:])
+}
+
+func (p PassphraseKeyProvider) deriveKey(salt []byte) ([]byte, error) {
+	return scrypt.Key(
+		p.passphrase,
+		salt,
+		scryptN,
+		scryptR,
+		scryptP,
+		dataKeyLength,
+	)
+}
+
+// FileKeyProvider uses a base64 encoded
+// 256-bit key stored in a local file.
+type FileKeyProvider struct {
+	key []byte
+}
+
+func NewFileKeyProvider(keyFilePath string) (*FileKeyProvider, error) {
+	keyFileContent, err := os.ReadFile(keyFilePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(
+		strings.TrimSpace(string(keyFileContent)),
+	)
+
+	if err != nil || len(key) != dataKeyLength {
+		return nil, ErrInvalidKeyFile
+	}
+
+	return &FileKeyProvider{
+		key: key,
+	}, nil
+}
+
+// CreateKeyFile generates a new random key and writes
+// it to the passed path, readable only by the current user.
+func CreateKeyFile(keyFilePath string) error {
+	key, err := generateDataKey()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(
+		keyFilePath,
+		[]byte(base64.StdEncoding.EncodeToString(key)+"\n"),
+		0600,
+	)
+}
+
+func (*FileKeyProvider) ID() string {
+	return FileKeyProviderID
+}
+
+func (f *FileKeyProvider) EncryptDataKey(dataKey []byte) ([]byte, error) {
+	return seal(f.key, dataKey)
+}
+
+func (f *FileKeyProvider) DecryptDataKey(encryptedDataKey []byte) ([]byte, error) {
+	return open(f.key, encryptedDataKey)
+}
+
+// CloudKMSKeyProvider delegates the data key encryption
+// to the key management service of the cloud provider.
+type CloudKMSKeyProvider struct {
+	stepper  stepper.Stepper
+	cloudKMS entities.CloudServiceKMS
+}
+
+func NewCloudKMSKeyProvider(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+) (*CloudKMSKeyProvider, error) {
+
+	cloudKMS, ok := entities.LookupCloudServiceKMS(cloudService)
+
+	if !ok {
+		return nil, ErrKMSNotSupported
+	}
+
+	return &CloudKMSKeyProvider{
+		stepper:  stepper,
+		cloudKMS: cloudKMS,
+	}, nil
+}
+
+func (*CloudKMSKeyProvider) ID() string {
+	return CloudKMSKeyProviderID
+}
+
+func (c *CloudKMSKeyProvider) EncryptDataKey(dataKey []byte) ([]byte, error) {
+	return c.cloudKMS.EncryptDataKey(c.stepper, dataKey)
+}
+
+func (c *CloudKMSKeyProvider) DecryptDataKey(encryptedDataKey []byte) ([]byte, error) {
+	return c.cloudKMS.DecryptDataKey(c.stepper, encryptedDataKey)
+}
@@ -0,0 +1,197 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+)
+
+func TestPassphraseKeyProvider(t *testing.T) {
+	keyProvider := NewPassphraseKeyProvider("passphrase")
+	dataKey := bytes.Repeat([]byte("k"), dataKeyLength)
+
+	encryptedDataKey, err := keyProvider.EncryptDataKey(dataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if bytes.Contains(encryptedDataKey, dataKey) {
+		t.Fatalf("expected data key to be encrypted")
+	}
+
+	decryptedDataKey, err := keyProvider.DecryptDataKey(encryptedDataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !bytes.Equal(dataKey, decryptedDataKey) {
+		t.Fatalf(
+			"expected decrypted data key to equal '%s', got '%s'",
+			dataKey,
+			decryptedDataKey,
+		)
+	}
+
+	_, err = NewPassphraseKeyProvider("invalid").DecryptDataKey(encryptedDataKey)
+
+	if err == nil {
+		t.Fatalf("expected error with invalid passphrase, got nothing")
+	}
+
+	_, err = keyProvider.DecryptDataKey([]byte("short"))
+
+	if err == nil {
+		t.Fatalf("expected error with invalid encrypted data key, got nothing")
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	keyFilePath := filepath.Join(t.TempDir(), "eleven.key")
+
+	err := CreateKeyFile(keyFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	keyFileInfo, err := os.Stat(keyFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if keyFileInfo.Mode().Perm() != 0600 {
+		t.Fatalf(
+			"expected key file permissions to equal '0600', got '%o'",
+			keyFileInfo.Mode().Perm(),
+		)
+	}
+
+	keyProvider, err := NewFileKeyProvider(keyFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	dataKey := bytes.Repeat([]byte("k"), dataKeyLength)
+	encryptedDataKey, err := keyProvider.EncryptDataKey(dataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	decryptedDataKey, err := keyProvider.DecryptDataKey(encryptedDataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !bytes.Equal(dataKey, decryptedDataKey) {
+		t.Fatalf(
+			"expected decrypted data key to equal '%s', got '%s'",
+			dataKey,
+			decryptedDataKey,
+		)
+	}
+}
+
+func TestNewFileKeyProviderWithInvalidKeyFiles(t *testing.T) {
+	testCases := []struct {
+		test           string
+		keyFileContent string
+	}{
+		{
+			test:           "with non base64 content",
+			keyFileContent: "not base64 !",
+		},
+
+		{
+			test:           "with too short key",
+			keyFileContent: "c2hvcnQ=",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			keyFilePath := filepath.Join(t.TempDir(), "eleven.key")
+
+			err := os.WriteFile(keyFilePath, []byte(tc.keyFileContent), 0600)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			_, err = NewFileKeyProvider(keyFilePath)
+
+			if !errors.Is(err, ErrInvalidKeyFile) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrInvalidKeyFile,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestNewCloudKMSKeyProviderWithoutKMS(t *testing.T) {
+	_, err := NewCloudKMSKeyProvider(nil, &fakeCloudService{})
+
+	if !errors.Is(err, ErrKMSNotSupported) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrKMSNotSupported,
+			err,
+		)
+	}
+}
+
+func TestNewCloudKMSKeyProviderWithWrappedKMS(t *testing.T) {
+	wrappedCloudService := NewCloudService(
+		&fakeKMSCloudService{},
+		NewPassphraseKeyProvider("passphrase"),
+	)
+
+	_, err := NewCloudKMSKeyProvider(nil, wrappedCloudService)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestCloudKMSKeyProvider(t *testing.T) {
+	keyProvider, err := NewCloudKMSKeyProvider(nil, &fakeKMSCloudService{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	dataKey := bytes.Repeat([]byte("k"), dataKeyLength)
+	encryptedDataKey, err := keyProvider.EncryptDataKey(dataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	decryptedDataKey, err := keyProvider.DecryptDataKey(encryptedDataKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !bytes.Equal(dataKey, decryptedDataKey) {
+		t.Fatalf(
+			"expected decrypted data key to equal '%s', got '%s'",
+			dataKey,
+			decryptedDataKey,
+		)
+	}
+}
+
+var _ entities.CloudServiceKMS = &fakeKMSCloudService{}
@@ -14,13 +14,140 @@ type CloudService interface {
 
 	CheckInstanceTypeValidity(stepper.Stepper, string) error
 
+	LookupPriceTable(stepper.Stepper) (*PriceTable, error)
+
+	// CreateEnv must create the EnvViewerUsername
+	// user used by the env's viewers
 	CreateEnv(stepper.Stepper, *Config, *Cluster, *Env) error
 	RemoveEnv(stepper.Stepper, *Config, *Cluster, *Env) error
 
+	// StartEnv must update the
+	// env's public IP address if it changes
+	StopEnv(stepper.Stepper, *Config, *Cluster, *Env) error
+	StartEnv(stepper.Stepper, *Config, *Cluster, *Env) error
+
+	// ResizeEnv must update the env's instance type on success
+	ResizeEnv(stepper.Stepper, *Config, *Cluster, *Env, string) error
+
+	LookupEnvActivity(stepper.Stepper, *Config, *Cluster, *Env) (*EnvActivity, error)
+
+	CreateSnapshot(stepper.Stepper, *Config, *Cluster, *Env, *Snapshot) error
+	// RestoreSnapshot creates the passed env
+	// using the snapshot's disk instead of a fresh image.
+	// The snapshot belongs to the passed cluster unless the
+	// cloud service implements CloudServiceCrossClusterRestorer.
+	RestoreSnapshot(stepper.Stepper, *Config, *Cluster, *Env, *Snapshot) error
+	// ListSnapshots returns the snapshots that
+	// exist in the cloud service for the passed cluster
+	ListSnapshots(stepper.Stepper, *Config, *Cluster) ([]*Snapshot, error)
+	RemoveSnapshot(stepper.Stepper, *Config, *Cluster, *Snapshot) error
+
+	// UpdateEnvAuthorizedKeys must write the content returned
+	// by the env's BuildAuthorizedKeysFileContent method
+	UpdateEnvAuthorizedKeys(stepper.Stepper, *Config, *Cluster, *Env) error
+
+	// Labels must be propagated to the cloud resource tags
+	// on creation and each time the tag update methods are called
+	UpdateEnvTags(stepper.Stepper, *Config, *Cluster, *Env) error
+	UpdateClusterTags(stepper.Stepper, *Config, *Cluster) error
+
 	OpenPort(stepper.Stepper, *Config, *Cluster, *Env, string) error
 	ClosePort(stepper.Stepper, *Config, *Cluster, *Env, string) error
 }
 
+// CloudServiceKMS could be implemented by the cloud services
+// that provide a key management service. It is used to
+// encrypt the data key protecting the Eleven config.
+type CloudServiceKMS interface {
+	EncryptDataKey(stepper.Stepper, []byte) ([]byte, error)
+	DecryptDataKey(stepper.Stepper, []byte) ([]byte, error)
+}
+
+// CloudServiceConnectivityChecker could be implemented by the
+// cloud services to let users diagnose their credentials and
+// network access without having to look up the config.
+type CloudServiceConnectivityChecker interface {
+	CheckConnectivity(stepper.Stepper) error
+}
+
+// CloudServiceCrossClusterRestorer could be implemented by the
+// cloud services able to restore a snapshot taken in a cluster
+// into another one (e.g. to move envs). CheckCrossClusterRestore
+// must return an error if the clusters are not compatible
+// (e.g. when they are located in different regions).
+type CloudServiceCrossClusterRestorer interface {
+	CheckCrossClusterRestore(
+		stepper stepper.Stepper,
+		elevenConfig *Config,
+		sourceCluster *Cluster,
+		targetCluster *Cluster,
+	) error
+}
+
+// CloudServiceWrapper must be implemented by the cloud
+// services that wrap another one given that embedding
+// hides the optional interfaces of the wrapped service.
+type CloudServiceWrapper interface {
+	Unwrap() CloudService
+}
+
+// LookupCloudServiceConnectivityChecker returns the connectivity
+// checker implemented by the passed cloud service or
+// by one of the cloud services that it wraps, if any.
+func LookupCloudServiceConnectivityChecker(
+	cloudService CloudService,
+) (CloudServiceConnectivityChecker, bool) {
+
+	return lookupCloudServiceInterface[CloudServiceConnectivityChecker](
+		cloudService,
+	)
+}
+
+// LookupCloudServiceCrossClusterRestorer is the equivalent
+// of LookupCloudServiceConnectivityChecker for the
+// CloudServiceCrossClusterRestorer interface.
+func LookupCloudServiceCrossClusterRestorer(
+	cloudService CloudService,
+) (CloudServiceCrossClusterRestorer, bool) {
+
+	return lookupCloudServiceInterface[CloudServiceCrossClusterRestorer](
+		cloudService,
+	)
+}
+
+// LookupCloudServiceKMS is the equivalent
+// of LookupCloudServiceConnectivityChecker for the
+// CloudServiceKMS interface.
+func LookupCloudServiceKMS(
+	cloudService CloudService,
+) (CloudServiceKMS, bool) {
+
+	return lookupCloudServiceInterface[CloudServiceKMS](
+		cloudService,
+	)
+}
+
+func lookupCloudServiceInterface[T any](cloudService CloudService) (T, bool) {
+	for cloudService != nil {
+		implementation, ok := cloudService.(T)
+
+		if ok {
+			return implementation, true
+		}
+
+		wrapper, ok := cloudService.(CloudServiceWrapper)
+
+		if !ok {
+			break
+		}
+
+		cloudService = wrapper.Unwrap()
+	}
+
+	var notImplemented T
+	return notImplemented, false
+}
+
 type CloudServiceBuilder interface {
 	Build() (CloudService, error)
 }
@@ -22,14 +22,19 @@ const (
 )
 
 type Cluster struct {
-	ID                  string          `json:"id"`
-	Name                string          `json:"name"`
-	DefaultInstanceType string          `json:"default_instance_type"`
-	InfrastructureJSON  string          `json:"infrastructure_json"`
-	Envs                map[string]*Env `json:"envs"`
-	IsDefault           bool            `json:"is_default"`
-	Status              ClusterStatus   `json:"status"`
-	CreatedAtTimestamp  int64           `json:"created_at_timestamp"`
+	ID                       string               `json:"id"`
+	Name                     string               `json:"name"`
+	Namespace                string               `json:"namespace"`
+	DefaultInstanceType      string               `json:"default_instance_type"`
+	InfrastructureJSON       string               `json:"infrastructure_json"`
+	Envs                     map[string]*Env      `json:"envs"`
+	Snapshots                map[string]*Snapshot `json:"snapshots"`
+	Labels                   Labels               `json:"labels"`
+	IdlePolicy               *IdlePolicy          `json:"idle_policy"`
+	IsDefault                bool                 `json:"is_default"`
+	Status                   ClusterStatus        `json:"status"`
+	StatusUpdatedAtTimestamp int64                `json:"status_updated_at_timestamp"`
+	CreatedAtTimestamp       int64                `json:"created_at_timestamp"`
 }
 
 func NewCluster(
@@ -38,21 +43,45 @@ func NewCluster(
 	isDefaultCluster bool,
 ) *Cluster {
 
+	now := time.Now().Unix()
+
 	return &Cluster{
-		ID:                  uuid.NewString(),
-		Name:                clusterName,
-		DefaultInstanceType: defaultInstanceType,
-		Envs:                map[string]*Env{},
-		IsDefault:           isDefaultCluster,
-		Status:              ClusterStatusCreating,
-		CreatedAtTimestamp:  time.Now().Unix(),
+		ID:                       uuid.NewString(),
+		Name:                     clusterName,
+		Namespace:                DefaultNamespaceName,
+		DefaultInstanceType:      defaultInstanceType,
+		Envs:                     map[string]*Env{},
+		Snapshots:                map[string]*Snapshot{},
+		Labels:                   Labels{},
+		IsDefault:                isDefaultCluster,
+		Status:                   ClusterStatusCreating,
+		StatusUpdatedAtTimestamp: now,
+		CreatedAtTimestamp:       now,
 	}
 }
 
+// SetStatus updates the status of the cluster
+// and records the time of the change
+func (c *Cluster) SetStatus(status ClusterStatus) {
+	c.Status = status
+	c.StatusUpdatedAtTimestamp = time.Now().Unix()
+}
+
 func (c *Cluster) GetNameSlug() string {
 	return strings.ReplaceAll(slug.Make(c.Name), "_", "-")
 }
 
+// GetSortedEnvs returns the envs of the cluster sorted by name
+func (c *Cluster) GetSortedEnvs() []*Env {
+	envs := []*Env{}
+
+	for _, envKey := range sortedEnvKeys(c) {
+		envs = append(envs, c.Envs[envKey])
+	}
+
+	return envs
+}
+
 func (c *Cluster) SetInfrastructureJSON(infrastructure interface{}) error {
 	infrastructureJSON, err := json.Marshal(infrastructure)
 
@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
+)
+
+const (
+	SnapshotNameRegExp    = EnvNameRegExp
+	SnapshotNameMaxLength = 32
+)
+
+type SnapshotStatus string
+
+const (
+	SnapshotStatusCreating SnapshotStatus = "creating"
+	SnapshotStatusCreated  SnapshotStatus = "created"
+	SnapshotStatusRemoving SnapshotStatus = "removing"
+)
+
+// Snapshot is a copy of the disk of an env that
+// could be used to create new envs in the same cluster.
+type Snapshot struct {
+	ID                 string         `json:"id"`
+	Name               string         `json:"name"`
+	SourceEnvName      string         `json:"source_env_name"`
+	InfrastructureJSON string         `json:"infrastructure_json"`
+	Status             SnapshotStatus `json:"status"`
+	CreatedAtTimestamp int64          `json:"created_at_timestamp"`
+}
+
+func NewSnapshot(snapshotName string, sourceEnv *Env) *Snapshot {
+	return &Snapshot{
+		ID:                 uuid.NewString(),
+		Name:               snapshotName,
+		SourceEnvName:      sourceEnv.Name,
+		Status:             SnapshotStatusCreating,
+		CreatedAtTimestamp: time.Now().Unix(),
+	}
+}
+
+func (s *Snapshot) SetInfrastructureJSON(infrastructure interface{}) error {
+	infrastructureJSON, err := json.Marshal(infrastructure)
+
+	if err != nil {
+		return err
+	}
+
+	s.InfrastructureJSON = string(infrastructureJSON)
+
+	return nil
+}
+
+func CheckSnapshotNameValidity(snapshotName string) error {
+	validSnapshotName := govalidator.Matches(
+		snapshotName,
+		SnapshotNameRegExp,
+	)
+
+	if !validSnapshotName || len(snapshotName) > SnapshotNameMaxLength {
+		return ErrInvalidSnapshotName{
+			SnapshotName:          snapshotName,
+			SnapshotNameRegExp:    SnapshotNameRegExp,
+			SnapshotNameMaxLength: SnapshotNameMaxLength,
+		}
+	}
+
+	return nil
+}
+
+func (c *Cluster) SnapshotExists(snapshotName string) bool {
+	_, snapshotExists := c.Snapshots[snapshotName]
+	return snapshotExists
+}
+
+func (c *Cluster) GetSnapshot(snapshotName string) (*Snapshot, error) {
+	if !c.SnapshotExists(snapshotName) {
+		return nil, ErrSnapshotNotExists{
+			ClusterName:  c.Name,
+			SnapshotName: snapshotName,
+		}
+	}
+
+	return c.Snapshots[snapshotName], nil
+}
+
+func (c *Cluster) SetSnapshot(snapshot *Snapshot) {
+	if c.Snapshots == nil {
+		c.Snapshots = map[string]*Snapshot{}
+	}
+
+	c.Snapshots[snapshot.Name] = snapshot
+}
+
+func (c *Cluster) RemoveSnapshot(snapshotName string) error {
+	if !c.SnapshotExists(snapshotName) {
+		return ErrSnapshotNotExists{
+			ClusterName:  c.Name,
+			SnapshotName: snapshotName,
+		}
+	}
+
+	delete(c.Snapshots, snapshotName)
+
+	return nil
+}
+
+// GetSortedSnapshots returns the snapshots of
+// the cluster sorted by creation date
+func (c *Cluster) GetSortedSnapshots() []*Snapshot {
+	snapshots := []*Snapshot{}
+
+	for _, snapshot := range c.Snapshots {
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].CreatedAtTimestamp == snapshots[j].CreatedAtTimestamp {
+			return snapshots[i].Name < snapshots[j].Name
+		}
+
+		return snapshots[i].CreatedAtTimestamp < snapshots[j].CreatedAtTimestamp
+	})
+
+	return snapshots
+}
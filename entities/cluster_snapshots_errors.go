@@ -0,0 +1,54 @@
+package entities
+
+type ErrInvalidSnapshotName struct {
+	SnapshotName          string
+	SnapshotNameRegExp    string
+	SnapshotNameMaxLength int
+}
+
+func (ErrInvalidSnapshotName) Error() string {
+	return "ErrInvalidSnapshotName"
+}
+
+type ErrSnapshotNotExists struct {
+	ClusterName  string
+	SnapshotName string
+}
+
+func (ErrSnapshotNotExists) Error() string {
+	return "ErrSnapshotNotExists"
+}
+
+type ErrSnapshotAlreadyExists struct {
+	ClusterName  string
+	SnapshotName string
+}
+
+func (ErrSnapshotAlreadyExists) Error() string {
+	return "ErrSnapshotAlreadyExists"
+}
+
+type ErrSnapshotNotCreated struct {
+	SnapshotName string
+	Status       SnapshotStatus
+}
+
+func (ErrSnapshotNotCreated) Error() string {
+	return "ErrSnapshotNotCreated"
+}
+
+type ErrSnapshotRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrSnapshotRemovingEnv) Error() string {
+	return "ErrSnapshotRemovingEnv"
+}
+
+type ErrSnapshotCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrSnapshotCreatingEnv) Error() string {
+	return "ErrSnapshotCreatingEnv"
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckSnapshotNameValidity(t *testing.T) {
+	testCases := []struct {
+		test          string
+		snapshotName  string
+		expectedError bool
+	}{
+		{
+			test:          "with valid name",
+			snapshotName:  "before-upgrade-2",
+			expectedError: false,
+		},
+
+		{
+			test:          "with invalid characters",
+			snapshotName:  "Before_Upgrade",
+			expectedError: true,
+		},
+
+		{
+			test:          "with too long name",
+			snapshotName:  strings.Repeat("a", SnapshotNameMaxLength+1),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckSnapshotNameValidity(tc.snapshotName)
+
+			if tc.expectedError && !errors.As(err, &ErrInvalidSnapshotName{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrInvalidSnapshotName{},
+					err,
+				)
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+		})
+	}
+}
+
+func TestClusterSnapshots(t *testing.T) {
+	cluster := &Cluster{Name: DefaultClusterName}
+	env := &Env{Name: "env"}
+
+	_, err := cluster.GetSnapshot("snapshot")
+
+	if !errors.As(err, &ErrSnapshotNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrSnapshotNotExists{},
+			err,
+		)
+	}
+
+	snapshot1 := NewSnapshot("snapshot1", env)
+	snapshot1.CreatedAtTimestamp = 2000
+
+	snapshot2 := NewSnapshot("snapshot2", env)
+	snapshot2.CreatedAtTimestamp = 1000
+
+	cluster.SetSnapshot(snapshot1)
+	cluster.SetSnapshot(snapshot2)
+
+	snapshot, err := cluster.GetSnapshot("snapshot1")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if snapshot.SourceEnvName != "env" || snapshot.Status != SnapshotStatusCreating {
+		t.Fatalf("expected snapshot of 'env' in creating state, got '%+v'", snapshot)
+	}
+
+	sortedSnapshots := cluster.GetSortedSnapshots()
+
+	if len(sortedSnapshots) != 2 ||
+		sortedSnapshots[0].Name != "snapshot2" ||
+		sortedSnapshots[1].Name != "snapshot1" {
+
+		t.Fatalf("expected snapshots to be sorted by creation date, got '%+v'", sortedSnapshots)
+	}
+
+	err = cluster.RemoveSnapshot("snapshot1")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if cluster.SnapshotExists("snapshot1") {
+		t.Fatalf("expected snapshot to be removed")
+	}
+
+	err = cluster.RemoveSnapshot("snapshot1")
+
+	if !errors.As(err, &ErrSnapshotNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrSnapshotNotExists{},
+			err,
+		)
+	}
+}
@@ -1,21 +1,142 @@
 package entities
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ConfigEncryption holds the data key used to encrypt
+// the sensitive fields of a config. The data key is
+// itself encrypted by the key provider whose ID is stored.
+type ConfigEncryption struct {
+	KeyProviderID    string `json:"key_provider_id"`
+	EncryptedDataKey string `json:"encrypted_data_key"`
+}
+
 type Config struct {
-	ID                 string              `json:"id"`
-	Clusters           map[string]*Cluster `json:"clusters"`
-	CreatedAtTimestamp int64               `json:"created_at_timestamp"`
+	ID            string                `json:"id"`
+	SchemaVersion int                   `json:"schema_version"`
+	Namespaces    map[string]*Namespace `json:"namespaces"`
+	Clusters      map[string]*Cluster   `json:"clusters"`
+	Encryption    *ConfigEncryption     `json:"encryption,omitempty"`
+	Quotas        ConfigQuotas          `json:"quotas"`
+	Members       ConfigMembers         `json:"members"`
+	// The audit log is unexported to make sure
+	// that it is only modified by appending entries
+	auditLog           []AuditLogEntry
+	CreatedAtTimestamp int64 `json:"created_at_timestamp"`
 }
 
 func NewConfig() *Config {
 	return &Config{
-		ID:                 uuid.NewString(),
+		ID:            uuid.NewString(),
+		SchemaVersion: ConfigSchemaVersion,
+		Namespaces: map[string]*Namespace{
+			DefaultNamespaceName: NewNamespace(DefaultNamespaceName),
+		},
 		Clusters:           map[string]*Cluster{},
+		Quotas:             NewConfigQuotas(),
+		Members:            ConfigMembers{},
+		auditLog:           []AuditLogEntry{},
 		CreatedAtTimestamp: time.Now().Unix(),
 	}
 }
+
+// rawConfig prevents infinite recursion
+// when encoding and decoding configs
+type rawConfig Config
+
+// rawConfigWithAuditLog is used to encode
+// and decode the unexported audit log
+type rawConfigWithAuditLog struct {
+	rawConfig
+	AuditLog []AuditLogEntry `json:"audit_log"`
+}
+
+// UnmarshalJSON migrates the passed JSON to the
+// current schema version before decoding it. That way, configs
+// are transparently upgraded when they are looked up.
+func (c *Config) UnmarshalJSON(configJSON []byte) error {
+	migratedConfigJSON, err := MigrateConfigJSON(configJSON)
+
+	if err != nil {
+		return err
+	}
+
+	var decodedConfig rawConfigWithAuditLog
+
+	err = json.Unmarshal(migratedConfigJSON, &decodedConfig)
+
+	if err != nil {
+		return err
+	}
+
+	*c = Config(decodedConfig.rawConfig)
+	c.auditLog = decodedConfig.AuditLog
+
+	return nil
+}
+
+// MarshalJSON refuses to encode configs written by a newer
+// version of Eleven given that the fields unknown to the
+// running binary would be lost.
+func (c Config) MarshalJSON() ([]byte, error) {
+	err := c.CheckSchemaVersion()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rawConfigWithAuditLog{
+		rawConfig: rawConfig(c),
+		AuditLog:  c.auditLog,
+	})
+}
+
+func (c *Config) CheckSchemaVersion() error {
+	if c.SchemaVersion > ConfigSchemaVersion {
+		return ErrConfigSchemaVersionTooNew{
+			SchemaVersion:          c.SchemaVersion,
+			SupportedSchemaVersion: ConfigSchemaVersion,
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) Clone() (*Config, error) {
+	configJSON, err := json.Marshal(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var clonedConfig *Config
+	err = json.Unmarshal(configJSON, &clonedConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clonedConfig, nil
+}
+
+// EncryptedSensitiveFieldPrefix marks the sensitive fields that
+// are encrypted at rest. User values must never start with it.
+const EncryptedSensitiveFieldPrefix = "eleven-encrypted:v1:"
+
+// SensitiveFields returns pointers to all the
+// fields that must be encrypted at rest.
+func (c *Config) SensitiveFields() []*string {
+	sensitiveFields := []*string{}
+
+	for _, cluster := range c.Clusters {
+		for _, env := range cluster.Envs {
+			sensitiveFields = append(sensitiveFields, env.SensitiveFields()...)
+		}
+	}
+
+	return sensitiveFields
+}
@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditLogUnknownActor = "unknown"
+	// The audit log is stored in the config so the oldest
+	// entries are dropped to prevent it from growing forever
+	AuditLogMaxEntries = 1000
+	// Inputs fields tagged with `audit:"-"`
+	// are never recorded (e.g. secret values)
+	auditLogStructTag = "audit"
+)
+
+type AuditLogEntryOutcome string
+
+const (
+	AuditLogEntryOutcomeSuccess AuditLogEntryOutcome = "success"
+	AuditLogEntryOutcomeFailure AuditLogEntryOutcome = "failure"
+)
+
+type AuditLogEntry struct {
+	ID                 string               `json:"id"`
+	Feature            string               `json:"feature"`
+	Inputs             map[string]string    `json:"inputs"`
+	EnvName            string               `json:"env_name"`
+	Actor              string               `json:"actor"`
+	Outcome            AuditLogEntryOutcome `json:"outcome"`
+	Error              string               `json:"error"`
+	StartedAtTimestamp int64                `json:"started_at_timestamp"`
+	EndedAtTimestamp   int64                `json:"ended_at_timestamp"`
+}
+
+type AuditLogFilter struct {
+	EnvName string
+	Actor   string
+	Since   time.Time
+	Until   time.Time
+}
+
+func NewAuditLogEntry(
+	feature string,
+	actor string,
+	inputs map[string]string,
+	startedAt time.Time,
+) *AuditLogEntry {
+
+	if len(actor) == 0 {
+		actor = AuditLogUnknownActor
+	}
+
+	return &AuditLogEntry{
+		ID:                 uuid.NewString(),
+		Feature:            feature,
+		Inputs:             inputs,
+		EnvName:            inputs["EnvName"],
+		Actor:              actor,
+		StartedAtTimestamp: startedAt.Unix(),
+	}
+}
+
+func (a *AuditLogEntry) End(endedAt time.Time, err error) {
+	a.EndedAtTimestamp = endedAt.Unix()
+	a.Outcome = AuditLogEntryOutcomeSuccess
+
+	if err != nil {
+		a.Outcome = AuditLogEntryOutcomeFailure
+		a.Error = err.Error()
+	}
+}
+
+// AppendAuditLogEntry is the only way to modify
+// the audit log given that it is append-only.
+// The oldest entries are dropped once the
+// log reaches AuditLogMaxEntries entries.
+func (c *Config) AppendAuditLogEntry(entry AuditLogEntry) {
+	c.auditLog = append(c.auditLog, entry)
+
+	if len(c.auditLog) > AuditLogMaxEntries {
+		nbOfDroppedEntries := len(c.auditLog) - AuditLogMaxEntries
+		c.auditLog = append(
+			[]AuditLogEntry{},
+			c.auditLog[nbOfDroppedEntries:]...,
+		)
+	}
+}
+
+func (c *Config) QueryAuditLog(filter AuditLogFilter) []AuditLogEntry {
+	entries := []AuditLogEntry{}
+
+	for _, entry := range c.auditLog {
+		if len(filter.EnvName) > 0 && entry.EnvName != filter.EnvName {
+			continue
+		}
+
+		if len(filter.Actor) > 0 && entry.Actor != filter.Actor {
+			continue
+		}
+
+		if !filter.Since.IsZero() && entry.StartedAtTimestamp < filter.Since.Unix() {
+			continue
+		}
+
+		if !filter.Until.IsZero() && entry.StartedAtTimestamp > filter.Until.Unix() {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// BuildAuditLogEntryInputs extracts the scalar exported fields
+// of a feature input. Callbacks, hooks and readers are ignored.
+func BuildAuditLogEntryInputs(input interface{}) map[string]string {
+	inputs := map[string]string{}
+
+	inputValue := reflect.ValueOf(input)
+
+	if inputValue.Kind() == reflect.Ptr {
+		inputValue = inputValue.Elem()
+	}
+
+	if inputValue.Kind() != reflect.Struct {
+		return inputs
+	}
+
+	inputType := inputValue.Type()
+
+	for fieldIndex := 0; fieldIndex < inputType.NumField(); fieldIndex++ {
+		field := inputType.Field(fieldIndex)
+
+		if !field.IsExported() || field.Tag.Get(auditLogStructTag) == "-" {
+			continue
+		}
+
+		fieldValue := inputValue.Field(fieldIndex)
+
+		switch fieldValue.Kind() {
+		case reflect.String,
+			reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+
+			inputs[field.Name] = fmt.Sprintf("%v", fieldValue.Interface())
+		case reflect.Slice:
+			if fieldValue.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+
+			inputs[field.Name] = fmt.Sprintf("%v", fieldValue.Interface())
+		}
+	}
+
+	return inputs
+}
@@ -0,0 +1,252 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAuditLogEntry(t *testing.T) {
+	startedAt := time.Unix(1000, 0)
+
+	entry := NewAuditLogEntry(
+		"remove",
+		"",
+		map[string]string{"EnvName": "env"},
+		startedAt,
+	)
+
+	if entry.Actor != AuditLogUnknownActor {
+		t.Fatalf(
+			"expected actor to equal '%s', got '%s'",
+			AuditLogUnknownActor,
+			entry.Actor,
+		)
+	}
+
+	if entry.EnvName != "env" {
+		t.Fatalf("expected env name to equal 'env', got '%s'", entry.EnvName)
+	}
+
+	if entry.StartedAtTimestamp != startedAt.Unix() {
+		t.Fatalf(
+			"expected started at to equal %d, got %d",
+			startedAt.Unix(),
+			entry.StartedAtTimestamp,
+		)
+	}
+
+	if len(entry.ID) == 0 {
+		t.Fatalf("expected ID to be set, got nothing")
+	}
+}
+
+func TestAuditLogEntryEnd(t *testing.T) {
+	testCases := []struct {
+		test            string
+		err             error
+		expectedOutcome AuditLogEntryOutcome
+		expectedError   string
+	}{
+		{
+			test:            "with success",
+			expectedOutcome: AuditLogEntryOutcomeSuccess,
+		},
+
+		{
+			test:            "with failure",
+			err:             errors.New("failure"),
+			expectedOutcome: AuditLogEntryOutcomeFailure,
+			expectedError:   "failure",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			entry := NewAuditLogEntry("init", "actor", map[string]string{}, time.Unix(1000, 0))
+			entry.End(time.Unix(2000, 0), tc.err)
+
+			if entry.Outcome != tc.expectedOutcome {
+				t.Fatalf(
+					"expected outcome to equal '%s', got '%s'",
+					tc.expectedOutcome,
+					entry.Outcome,
+				)
+			}
+
+			if entry.Error != tc.expectedError {
+				t.Fatalf(
+					"expected error to equal '%s', got '%s'",
+					tc.expectedError,
+					entry.Error,
+				)
+			}
+
+			if entry.EndedAtTimestamp != 2000 {
+				t.Fatalf("expected ended at to equal 2000, got %d", entry.EndedAtTimestamp)
+			}
+		})
+	}
+}
+
+func TestConfigQueryAuditLog(t *testing.T) {
+	config := NewConfig()
+
+	config.AppendAuditLogEntry(AuditLogEntry{ID: "1", EnvName: "env1", Actor: "alice", StartedAtTimestamp: 1000})
+	config.AppendAuditLogEntry(AuditLogEntry{ID: "2", EnvName: "env2", Actor: "bob", StartedAtTimestamp: 2000})
+	config.AppendAuditLogEntry(AuditLogEntry{ID: "3", EnvName: "env1", Actor: "bob", StartedAtTimestamp: 3000})
+
+	testCases := []struct {
+		test        string
+		filter      AuditLogFilter
+		expectedIDs []string
+	}{
+		{
+			test:        "with no filter",
+			filter:      AuditLogFilter{},
+			expectedIDs: []string{"1", "2", "3"},
+		},
+
+		{
+			test:        "with env name filter",
+			filter:      AuditLogFilter{EnvName: "env1"},
+			expectedIDs: []string{"1", "3"},
+		},
+
+		{
+			test:        "with actor filter",
+			filter:      AuditLogFilter{Actor: "bob"},
+			expectedIDs: []string{"2", "3"},
+		},
+
+		{
+			test: "with time range filter",
+			filter: AuditLogFilter{
+				Since: time.Unix(1500, 0),
+				Until: time.Unix(2500, 0),
+			},
+			expectedIDs: []string{"2"},
+		},
+
+		{
+			test: "with non-matching filters",
+			filter: AuditLogFilter{
+				EnvName: "env2",
+				Actor:   "alice",
+			},
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			entries := config.QueryAuditLog(tc.filter)
+
+			entriesIDs := []string{}
+			for _, entry := range entries {
+				entriesIDs = append(entriesIDs, entry.ID)
+			}
+
+			if !reflect.DeepEqual(tc.expectedIDs, entriesIDs) {
+				t.Fatalf(
+					"expected entries to equal '%+v', got '%+v'",
+					tc.expectedIDs,
+					entriesIDs,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigAppendAuditLogEntryDropsOldestEntries(t *testing.T) {
+	config := NewConfig()
+
+	for entryIndex := 0; entryIndex < AuditLogMaxEntries+2; entryIndex++ {
+		config.AppendAuditLogEntry(AuditLogEntry{
+			ID: fmt.Sprintf("%d", entryIndex),
+		})
+	}
+
+	entries := config.QueryAuditLog(AuditLogFilter{})
+
+	if len(entries) != AuditLogMaxEntries {
+		t.Fatalf(
+			"expected %d entries, got %d",
+			AuditLogMaxEntries,
+			len(entries),
+		)
+	}
+
+	if entries[0].ID != "2" {
+		t.Fatalf("expected first entry ID to equal '2', got '%s'", entries[0].ID)
+	}
+}
+
+func TestConfigAuditLogJSON(t *testing.T) {
+	config := NewConfig()
+	config.AppendAuditLogEntry(AuditLogEntry{ID: "1", Actor: "alice"})
+
+	configJSON, err := json.Marshal(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	var decodedConfig *Config
+	err = json.Unmarshal(configJSON, &decodedConfig)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	entries := decodedConfig.QueryAuditLog(AuditLogFilter{})
+
+	if len(entries) != 1 || entries[0].Actor != "alice" {
+		t.Fatalf("expected audit log to be decoded, got '%+v'", entries)
+	}
+}
+
+func TestBuildAuditLogEntryInputs(t *testing.T) {
+	type testInput struct {
+		EnvName      string
+		Repositories []string
+		Force        bool
+		Port         int
+		Secret       string `audit:"-"`
+		Callback     func() error
+		unexported   string
+	}
+
+	inputs := BuildAuditLogEntryInputs(&testInput{
+		EnvName:      "env",
+		Repositories: []string{"eleven-sh/api"},
+		Force:        true,
+		Port:         8080,
+		Secret:       "secret",
+		unexported:   "unexported",
+	})
+
+	expectedInputs := map[string]string{
+		"EnvName":      "env",
+		"Repositories": "[eleven-sh/api]",
+		"Force":        "true",
+		"Port":         "8080",
+	}
+
+	if !reflect.DeepEqual(expectedInputs, inputs) {
+		t.Fatalf(
+			"expected inputs to equal '%+v', got '%+v'",
+			expectedInputs,
+			inputs,
+		)
+	}
+
+	inputs = BuildAuditLogEntryInputs("not_a_struct")
+
+	if len(inputs) != 0 {
+		t.Fatalf("expected no inputs, got '%+v'", inputs)
+	}
+}
@@ -40,3 +40,14 @@ func (c *Config) RemoveCluster(clusterName string) error {
 
 	return nil
 }
+
+// GetSortedClusters returns the clusters of the config sorted by name
+func (c *Config) GetSortedClusters() []*Cluster {
+	clusters := []*Cluster{}
+
+	for _, clusterKey := range c.sortedClusterKeys() {
+		clusters = append(clusters, c.Clusters[clusterKey])
+	}
+
+	return clusters
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -139,3 +140,32 @@ func TestConfigRemoveCluster(t *testing.T) {
 		t.Fatalf("expected cluster to not exist")
 	}
 }
+
+func TestConfigGetSortedClusters(t *testing.T) {
+	config := NewConfig()
+
+	for _, clusterName := range []string{"c", "a", "b"} {
+		cluster := NewCluster(clusterName, "default_instance_type", false)
+
+		for _, envName := range []string{"env2", "env1"} {
+			cluster.Envs[envName] = &Env{Name: envName}
+		}
+
+		config.SetCluster(cluster)
+	}
+
+	clusterNames := []string{}
+	for _, cluster := range config.GetSortedClusters() {
+		clusterNames = append(clusterNames, cluster.Name)
+
+		envs := cluster.GetSortedEnvs()
+
+		if len(envs) != 2 || envs[0].Name != "env1" || envs[1].Name != "env2" {
+			t.Fatalf("expected envs to be sorted by name, got '%+v'", envs)
+		}
+	}
+
+	if strings.Join(clusterNames, ",") != "a,b,c" {
+		t.Fatalf("expected clusters to be sorted by name, got '%+v'", clusterNames)
+	}
+}
@@ -54,6 +54,49 @@ func (c *Config) RemoveEnv(clusterName, envName string) error {
 	return nil
 }
 
+// RenameEnv moves the env to its new name and regenerates
+// its local SSH config hostname. The env ID is kept.
+func (c *Config) RenameEnv(
+	clusterName string,
+	envName string,
+	newEnvName string,
+	localSSHCfgDupHostCt int,
+) error {
+
+	env, err := c.GetEnv(clusterName, envName)
+
+	if err != nil {
+		return err
+	}
+
+	if c.EnvExists(clusterName, newEnvName) {
+		return ErrEnvAlreadyExists{
+			ClusterName: clusterName,
+			EnvName:     newEnvName,
+		}
+	}
+
+	cluster := c.Clusters[clusterName]
+
+	delete(cluster.Envs, envName)
+
+	env.Name = newEnvName
+	env.LocalSSHConfigHostname = buildLocalSSHCfgHostnameForEnv(
+		newEnvName,
+		localSSHCfgDupHostCt,
+	)
+
+	cluster.Envs[newEnvName] = env
+
+	for _, snapshot := range cluster.Snapshots {
+		if snapshot.SourceEnvName == envName {
+			snapshot.SourceEnvName = newEnvName
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) CountEnvsInCluster(clusterName string) (int, error) {
 	if !c.ClusterExists(clusterName) {
 		return 0, ErrClusterNotExists{
@@ -274,6 +274,94 @@ func TestConfigRemoveEnv(t *testing.T) {
 	}
 }
 
+func TestConfigRenameEnv(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(
+		"cluster_name",
+		"default_instance_type",
+		true,
+	)
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+	otherEnv := NewEnv(
+		"other_env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	config.Clusters[cluster.Name] = cluster
+	cluster.Envs[env.Name] = env
+	cluster.Envs[otherEnv.Name] = otherEnv
+
+	snapshot := NewSnapshot("snapshot", env)
+	cluster.SetSnapshot(snapshot)
+
+	err := config.RenameEnv(cluster.Name, "unknown_env", "new_env", 0)
+
+	if err == nil || !errors.As(err, &ErrEnvNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrEnvNotExists{},
+			err,
+		)
+	}
+
+	err = config.RenameEnv(cluster.Name, env.Name, otherEnv.Name, 0)
+
+	if err == nil || !errors.As(err, &ErrEnvAlreadyExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrEnvAlreadyExists{},
+			err,
+		)
+	}
+
+	envID := env.ID
+	err = config.RenameEnv(cluster.Name, env.Name, "new-env", 2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if config.EnvExists(cluster.Name, "env_name") {
+		t.Fatalf("expected old env name to be removed")
+	}
+
+	renamedEnv, err := config.GetEnv(cluster.Name, "new-env")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if renamedEnv.ID != envID || renamedEnv.Name != "new-env" {
+		t.Fatalf(
+			"expected env to be renamed with same ID, got '%+v'",
+			renamedEnv,
+		)
+	}
+
+	if renamedEnv.LocalSSHConfigHostname != "eleven/new-env-2" {
+		t.Fatalf(
+			"expected local SSH config hostname to equal 'eleven/new-env-2', got '%s'",
+			renamedEnv.LocalSSHConfigHostname,
+		)
+	}
+
+	if snapshot.SourceEnvName != "new-env" {
+		t.Fatalf(
+			"expected snapshot source env name to equal 'new-env', got '%s'",
+			snapshot.SourceEnvName,
+		)
+	}
+}
+
 func TestConfigCountEnvsInCluster(t *testing.T) {
 	config := NewConfig()
 	cluster := NewCluster(
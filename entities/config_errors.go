@@ -0,0 +1,140 @@
+package entities
+
+type ErrConfigSchemaVersionTooNew struct {
+	SchemaVersion          int
+	SupportedSchemaVersion int
+}
+
+func (ErrConfigSchemaVersionTooNew) Error() string {
+	return "ErrConfigSchemaVersionTooNew"
+}
+
+type ErrConfigMigrationFailed struct {
+	ToSchemaVersion int
+	ReturnedError   error
+}
+
+func (ErrConfigMigrationFailed) Error() string {
+	return "ErrConfigMigrationFailed"
+}
+
+func (e ErrConfigMigrationFailed) Unwrap() error {
+	return e.ReturnedError
+}
+
+type ErrInvalidConfigExport struct {
+	Reason string
+}
+
+func (ErrInvalidConfigExport) Error() string {
+	return "ErrInvalidConfigExport"
+}
+
+type ErrInvalidConfigExportSecretsMode struct {
+	SecretsMode string
+}
+
+func (ErrInvalidConfigExportSecretsMode) Error() string {
+	return "ErrInvalidConfigExportSecretsMode"
+}
+
+type ErrInconsistentConfig struct {
+	Reason string
+}
+
+func (ErrInconsistentConfig) Error() string {
+	return "ErrInconsistentConfig"
+}
+
+type ErrImportExistingConfig struct {
+	NbOfExistingClusters int
+}
+
+func (ErrImportExistingConfig) Error() string {
+	return "ErrImportExistingConfig"
+}
+
+type ErrUninstallRemovingEnvs struct {
+	NbOfFailedRemovals int
+}
+
+func (ErrUninstallRemovingEnvs) Error() string {
+	return "ErrUninstallRemovingEnvs"
+}
+
+type ErrQuotaExceeded struct {
+	Quota   QuotaType
+	Limit   float64
+	Current float64
+	// Namespace is empty when the quota
+	// is set for the whole installation
+	Namespace string
+}
+
+func (ErrQuotaExceeded) Error() string {
+	return "ErrQuotaExceeded"
+}
+
+type ErrInstanceTypeNotAllowed struct {
+	InstanceType         string
+	AllowedInstanceTypes []string
+}
+
+func (ErrInstanceTypeNotAllowed) Error() string {
+	return "ErrInstanceTypeNotAllowed"
+}
+
+type ErrPermissionDenied struct {
+	Actor      string
+	Permission Permission
+	// Namespace is empty when the permission
+	// was checked for the whole installation
+	Namespace string
+}
+
+func (ErrPermissionDenied) Error() string {
+	return "ErrPermissionDenied"
+}
+
+type ErrInvalidMemberRole struct {
+	Role       string
+	ValidRoles []MemberRole
+}
+
+func (ErrInvalidMemberRole) Error() string {
+	return "ErrInvalidMemberRole"
+}
+
+type ErrConfigMemberNotExists struct {
+	Username string
+}
+
+func (ErrConfigMemberNotExists) Error() string {
+	return "ErrConfigMemberNotExists"
+}
+
+type ErrInvalidNamespaceName struct {
+	NamespaceName          string
+	NamespaceNameRegExp    string
+	NamespaceNameMaxLength int
+}
+
+func (ErrInvalidNamespaceName) Error() string {
+	return "ErrInvalidNamespaceName"
+}
+
+type ErrNamespaceNotExists struct {
+	NamespaceName string
+}
+
+func (ErrNamespaceNotExists) Error() string {
+	return "ErrNamespaceNotExists"
+}
+
+type ErrNamespaceNotEmpty struct {
+	NamespaceName string
+}
+
+func (ErrNamespaceNotEmpty) Error() string {
+	return "ErrNamespaceNotEmpty"
+}
@@ -0,0 +1,194 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ConfigExportFormatVersion is the version of the export file
+// envelope. The exported config has its own schema version.
+const ConfigExportFormatVersion = 1
+
+type ConfigExportSecretsMode string
+
+const (
+	ConfigExportSecretsModePlaintext ConfigExportSecretsMode = "plaintext"
+	ConfigExportSecretsModeRedacted  ConfigExportSecretsMode = "redacted"
+	ConfigExportSecretsModeEncrypted ConfigExportSecretsMode = "encrypted"
+)
+
+type ConfigExport struct {
+	FormatVersion       int                     `json:"format_version"`
+	SecretsMode         ConfigExportSecretsMode `json:"secrets_mode"`
+	ExportedAtTimestamp int64                   `json:"exported_at_timestamp"`
+	Config              *Config                 `json:"config"`
+}
+
+// NewConfigExport returns an export of a copy of the passed config.
+// Sensitive fields are removed when using the redacted mode.
+// Encryption, if requested, is the responsibility of the caller.
+func NewConfigExport(
+	config *Config,
+	secretsMode ConfigExportSecretsMode,
+) (*ConfigExport, error) {
+
+	err := CheckConfigExportSecretsModeValidity(secretsMode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exportedConfig, err := config.Clone()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The data key is bound to the key provider of the
+	// config storage that may not exist where the config is imported
+	exportedConfig.Encryption = nil
+
+	if secretsMode == ConfigExportSecretsModeRedacted {
+		exportedConfig.RedactSensitiveFields()
+	}
+
+	return &ConfigExport{
+		FormatVersion:       ConfigExportFormatVersion,
+		SecretsMode:         secretsMode,
+		ExportedAtTimestamp: time.Now().Unix(),
+		Config:              exportedConfig,
+	}, nil
+}
+
+func ParseConfigExport(configExportJSON []byte) (*ConfigExport, error) {
+	var configExport *ConfigExport
+	err := json.Unmarshal(configExportJSON, &configExport)
+
+	if err != nil {
+		return nil, ErrInvalidConfigExport{
+			Reason: err.Error(),
+		}
+	}
+
+	if configExport == nil || configExport.Config == nil {
+		return nil, ErrInvalidConfigExport{
+			Reason: "missing config",
+		}
+	}
+
+	if configExport.FormatVersion > ConfigExportFormatVersion {
+		return nil, ErrInvalidConfigExport{
+			Reason: fmt.Sprintf(
+				"unsupported format version %d",
+				configExport.FormatVersion,
+			),
+		}
+	}
+
+	err = CheckConfigExportSecretsModeValidity(configExport.SecretsMode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return configExport, nil
+}
+
+func CheckConfigExportSecretsModeValidity(secretsMode ConfigExportSecretsMode) error {
+	switch secretsMode {
+	case ConfigExportSecretsModePlaintext,
+		ConfigExportSecretsModeRedacted,
+		ConfigExportSecretsModeEncrypted:
+
+		return nil
+	}
+
+	return ErrInvalidConfigExportSecretsMode{
+		SecretsMode: string(secretsMode),
+	}
+}
+
+func (c *Config) RedactSensitiveFields() {
+	for _, field := range c.SensitiveFields() {
+		*field = ""
+	}
+}
+
+// CheckConsistency verifies that the config could be
+// safely used. It is run before importing a config.
+func (c *Config) CheckConsistency() error {
+	if len(c.ID) == 0 {
+		return ErrInconsistentConfig{
+			Reason: "missing config ID",
+		}
+	}
+
+	err := c.CheckSchemaVersion()
+
+	if err != nil {
+		return err
+	}
+
+	seenIDs := map[string]bool{}
+
+	for clusterName, cluster := range c.Clusters {
+		if cluster == nil {
+			return ErrInconsistentConfig{
+				Reason: fmt.Sprintf("cluster \"%s\" is empty", clusterName),
+			}
+		}
+
+		if cluster.Name != clusterName {
+			return ErrInconsistentConfig{
+				Reason: fmt.Sprintf(
+					"cluster \"%s\" is stored as \"%s\"",
+					cluster.Name,
+					clusterName,
+				),
+			}
+		}
+
+		if len(cluster.ID) == 0 || seenIDs[cluster.ID] {
+			return ErrInconsistentConfig{
+				Reason: fmt.Sprintf(
+					"cluster \"%s\" has a missing or duplicated ID",
+					clusterName,
+				),
+			}
+		}
+
+		seenIDs[cluster.ID] = true
+
+		for envName, env := range cluster.Envs {
+			if env == nil {
+				return ErrInconsistentConfig{
+					Reason: fmt.Sprintf("env \"%s\" is empty", envName),
+				}
+			}
+
+			if env.Name != envName {
+				return ErrInconsistentConfig{
+					Reason: fmt.Sprintf(
+						"env \"%s\" is stored as \"%s\"",
+						env.Name,
+						envName,
+					),
+				}
+			}
+
+			if len(env.ID) == 0 || seenIDs[env.ID] {
+				return ErrInconsistentConfig{
+					Reason: fmt.Sprintf(
+						"env \"%s\" has a missing or duplicated ID",
+						envName,
+					),
+				}
+			}
+
+			seenIDs[env.ID] = true
+		}
+	}
+
+	return nil
+}
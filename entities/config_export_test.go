@@ -0,0 +1,272 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func buildTestConfigWithEnv() (*Config, *Cluster, *Env) {
+	config := NewConfig()
+	cluster := NewCluster(
+		"cluster_name",
+		"default_instance_type",
+		true,
+	)
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	config.Clusters[cluster.Name] = cluster
+	cluster.Envs[env.Name] = env
+
+	return config, cluster, env
+}
+
+func TestNewConfigExport(t *testing.T) {
+	testCases := []struct {
+		test                  string
+		secretsMode           ConfigExportSecretsMode
+		expectedSSHKeyContent string
+	}{
+		{
+			test:                  "with plaintext secrets",
+			secretsMode:           ConfigExportSecretsModePlaintext,
+			expectedSSHKeyContent: "ssh_key_content",
+		},
+
+		{
+			test:                  "with redacted secrets",
+			secretsMode:           ConfigExportSecretsModeRedacted,
+			expectedSSHKeyContent: "",
+		},
+
+		{
+			test:                  "with encrypted secrets",
+			secretsMode:           ConfigExportSecretsModeEncrypted,
+			expectedSSHKeyContent: "ssh_key_content",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			config, cluster, env := buildTestConfigWithEnv()
+
+			env.SSHKeyPairPEMContent = "ssh_key_content"
+			config.Encryption = &ConfigEncryption{
+				KeyProviderID: "file",
+			}
+
+			configExport, err := NewConfigExport(config, tc.secretsMode)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if configExport.FormatVersion != ConfigExportFormatVersion ||
+				configExport.SecretsMode != tc.secretsMode {
+
+				t.Fatalf("expected export metadata to be set, got '%+v'", configExport)
+			}
+
+			if configExport.Config.Encryption != nil {
+				t.Fatalf("expected exported config to not contain data key")
+			}
+
+			exportedEnv := configExport.Config.Clusters[cluster.Name].Envs[env.Name]
+
+			if exportedEnv.SSHKeyPairPEMContent != tc.expectedSSHKeyContent {
+				t.Fatalf(
+					"expected exported SSH key to equal '%s', got '%s'",
+					tc.expectedSSHKeyContent,
+					exportedEnv.SSHKeyPairPEMContent,
+				)
+			}
+
+			if env.SSHKeyPairPEMContent != "ssh_key_content" ||
+				config.Encryption == nil {
+
+				t.Fatalf("expected exported config to be a copy")
+			}
+		})
+	}
+
+	config, _, _ := buildTestConfigWithEnv()
+	_, err := NewConfigExport(config, "invalid")
+
+	if err == nil || !errors.As(err, &ErrInvalidConfigExportSecretsMode{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidConfigExportSecretsMode{},
+			err,
+		)
+	}
+}
+
+func TestParseConfigExport(t *testing.T) {
+	config, _, _ := buildTestConfigWithEnv()
+	configExport, err := NewConfigExport(config, ConfigExportSecretsModePlaintext)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	configExportJSON, err := json.Marshal(configExport)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	parsedConfigExport, err := ParseConfigExport(configExportJSON)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if parsedConfigExport.Config.ID != config.ID {
+		t.Fatalf(
+			"expected config ID to equal '%s', got '%s'",
+			config.ID,
+			parsedConfigExport.Config.ID,
+		)
+	}
+
+	testCases := []struct {
+		test             string
+		configExportJSON string
+		expectedError    error
+	}{
+		{
+			test:             "with invalid JSON",
+			configExportJSON: "invalid_json",
+			expectedError:    ErrInvalidConfigExport{},
+		},
+
+		{
+			test:             "without config",
+			configExportJSON: `{"format_version": 1, "secrets_mode": "plaintext"}`,
+			expectedError:    ErrInvalidConfigExport{},
+		},
+
+		{
+			test:             "with newer format version",
+			configExportJSON: `{"format_version": 1000, "secrets_mode": "plaintext", "config": {}}`,
+			expectedError:    ErrInvalidConfigExport{},
+		},
+
+		{
+			test:             "with invalid secrets mode",
+			configExportJSON: `{"format_version": 1, "secrets_mode": "invalid", "config": {}}`,
+			expectedError:    ErrInvalidConfigExportSecretsMode{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseConfigExport([]byte(tc.configExportJSON))
+
+			if err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+
+			if err.Error() != tc.expectedError.Error() {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigCheckConsistency(t *testing.T) {
+	testCases := []struct {
+		test          string
+		alterConfig   func(config *Config, cluster *Cluster, env *Env)
+		expectedError error
+	}{
+		{
+			test:          "with consistent config",
+			alterConfig:   func(config *Config, cluster *Cluster, env *Env) {},
+			expectedError: nil,
+		},
+
+		{
+			test: "without config ID",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				config.ID = ""
+			},
+			expectedError: ErrInconsistentConfig{},
+		},
+
+		{
+			test: "with newer schema version",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				config.SchemaVersion = ConfigSchemaVersion + 1
+			},
+			expectedError: ErrConfigSchemaVersionTooNew{},
+		},
+
+		{
+			test: "with cluster key mismatch",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				cluster.Name = "other_name"
+			},
+			expectedError: ErrInconsistentConfig{},
+		},
+
+		{
+			test: "with env key mismatch",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				env.Name = "other_name"
+			},
+			expectedError: ErrInconsistentConfig{},
+		},
+
+		{
+			test: "with duplicated IDs",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				env.ID = cluster.ID
+			},
+			expectedError: ErrInconsistentConfig{},
+		},
+
+		{
+			test: "with nil env",
+			alterConfig: func(config *Config, cluster *Cluster, env *Env) {
+				cluster.Envs["nil_env"] = nil
+			},
+			expectedError: ErrInconsistentConfig{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			config, cluster, env := buildTestConfigWithEnv()
+			tc.alterConfig(config, cluster, env)
+
+			err := config.CheckConsistency()
+
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				return
+			}
+
+			if err == nil || err.Error() != tc.expectedError.Error() {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// ConfigSchemaVersion is the version of the config
+// JSON shape understood by the running binary.
+// It must be incremented each time a migration is added.
+const ConfigSchemaVersion = 11
+
+const configSchemaVersionJSONKey = "schema_version"
+
+type rawConfigJSON = map[string]interface{}
+
+// ConfigMigration upgrades a decoded config JSON
+// from the previous schema version to "ToSchemaVersion".
+type ConfigMigration struct {
+	ToSchemaVersion int
+	Migrate         func(config rawConfigJSON) error
+}
+
+// configMigrations must be sorted by schema version.
+// Migrations operate on raw JSON given that
+// the previous Go types no longer exist.
+var configMigrations = []ConfigMigration{
+	{
+		ToSchemaVersion: 1,
+		Migrate:         migrateConfigToV1,
+	},
+
+	{
+		ToSchemaVersion: 2,
+		Migrate:         migrateConfigToV2,
+	},
+
+	{
+		ToSchemaVersion: 3,
+		Migrate:         migrateConfigToV3,
+	},
+
+	{
+		ToSchemaVersion: 4,
+		Migrate:         migrateConfigToV4,
+	},
+
+	{
+		ToSchemaVersion: 5,
+		Migrate:         migrateConfigToV5,
+	},
+
+	{
+		ToSchemaVersion: 6,
+		Migrate:         migrateConfigToV6,
+	},
+
+	{
+		ToSchemaVersion: 7,
+		Migrate:         migrateConfigToV7,
+	},
+
+	{
+		ToSchemaVersion: 8,
+		Migrate:         migrateConfigToV8,
+	},
+
+	{
+		ToSchemaVersion: 9,
+		Migrate:         migrateConfigToV9,
+	},
+
+	{
+		ToSchemaVersion: 10,
+		Migrate:         migrateConfigToV10,
+	},
+
+	{
+		ToSchemaVersion: 11,
+		Migrate:         migrateConfigToV11,
+	},
+}
+
+// MigrateConfigJSON applies, step by step, all the migrations
+// needed to upgrade the passed config JSON to the current
+// schema version. Configs written by a newer version of Eleven
+// are returned as is.
+func MigrateConfigJSON(configJSON []byte) ([]byte, error) {
+	config, err := decodeRawConfigJSON(configJSON)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil { // "null"
+		return configJSON, nil
+	}
+
+	schemaVersion := getRawConfigSchemaVersion(config)
+
+	if schemaVersion >= ConfigSchemaVersion {
+		return configJSON, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.ToSchemaVersion <= schemaVersion {
+			continue
+		}
+
+		err := migration.Migrate(config)
+
+		if err != nil {
+			return nil, ErrConfigMigrationFailed{
+				ToSchemaVersion: migration.ToSchemaVersion,
+				ReturnedError:   err,
+			}
+		}
+
+		config[configSchemaVersionJSONKey] = migration.ToSchemaVersion
+		schemaVersion = migration.ToSchemaVersion
+	}
+
+	return json.Marshal(config)
+}
+
+// decodeRawConfigJSON decodes JSON numbers as json.Number
+// given that float64 can't represent all the int64 values
+// (e.g. IDs) and that migrated configs must not lose precision.
+func decodeRawConfigJSON(configJSON []byte) (rawConfigJSON, error) {
+	var config rawConfigJSON
+
+	decoder := json.NewDecoder(bytes.NewReader(configJSON))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Like json.Unmarshal, data after
+	// the config value is rejected
+	_, err = decoder.Token()
+
+	if err == io.EOF {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, ErrConfigTrailingData
+}
+
+func getRawConfigSchemaVersion(config rawConfigJSON) int {
+	// Configs created before versioning have no schema version
+	schemaVersion, _ := config[configSchemaVersionJSONKey].(json.Number)
+	parsedSchemaVersion, _ := schemaVersion.Int64()
+
+	return int(parsedSchemaVersion)
+}
+
+func getRawConfigObject(parent rawConfigJSON, key string) rawConfigJSON {
+	object, _ := parent[key].(map[string]interface{})
+	return object
+}
+
+// getRawConfigObjects returns the objects
+// stored as values of the passed map key
+func getRawConfigObjects(parent rawConfigJSON, key string) []rawConfigJSON {
+	objects := []rawConfigJSON{}
+
+	for _, rawObject := range getRawConfigObject(parent, key) {
+		object, ok := rawObject.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects
+}
+
+func setRawConfigDefault(parent rawConfigJSON, key string, defaultValue interface{}) {
+	if parent[key] == nil {
+		parent[key] = defaultValue
+	}
+}
+
+// migrateConfigToV1 replaces the "null" collections
+// written by the first versions of Eleven with empty ones.
+func migrateConfigToV1(config rawConfigJSON) error {
+	setRawConfigDefault(config, "clusters", map[string]interface{}{})
+
+	for _, rawCluster := range getRawConfigObject(config, "clusters") {
+		cluster, ok := rawCluster.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		setRawConfigDefault(cluster, "envs", map[string]interface{}{})
+
+		for _, rawEnv := range getRawConfigObject(cluster, "envs") {
+			env, ok := rawEnv.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			setRawConfigDefault(env, "ssh_host_keys", []interface{}{})
+			setRawConfigDefault(env, "repositories", []interface{}{})
+			setRawConfigDefault(env, "runtimes", map[string]interface{}{})
+			setRawConfigDefault(env, "served_ports", map[string]interface{}{})
+			setRawConfigDefault(env, "secrets", map[string]interface{}{})
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV2 adds the audit log
+func migrateConfigToV2(config rawConfigJSON) error {
+	setRawConfigDefault(config, "audit_log", []interface{}{})
+
+	return nil
+}
+
+// migrateConfigToV3 adds the labels of clusters and envs
+func migrateConfigToV3(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		setRawConfigDefault(cluster, "labels", map[string]interface{}{})
+
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(env, "labels", map[string]interface{}{})
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV4 adds the expiry of envs. Zero means no expiry.
+func migrateConfigToV4(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(env, "expires_at_timestamp", 0)
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV5 adds the idle policies of clusters
+// and envs. A "null" policy means no auto-stop.
+func migrateConfigToV5(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		setRawConfigDefault(cluster, "idle_policy", nil)
+
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(env, "idle_policy", nil)
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV6 adds the quotas (all unlimited)
+// and the owner of envs (unknown for existing ones)
+func migrateConfigToV6(config rawConfigJSON) error {
+	setRawConfigDefault(config, "quotas", map[string]interface{}{
+		"max_envs_per_cluster":   0,
+		"max_envs_per_owner":     0,
+		"allowed_instance_types": []interface{}{},
+		"max_monthly_spend":      0,
+	})
+
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(env, "owner", "")
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV7 adds the snapshots of clusters
+func migrateConfigToV7(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		setRawConfigDefault(cluster, "snapshots", map[string]interface{}{})
+	}
+
+	return nil
+}
+
+// migrateConfigToV8 adds the collaborators of envs
+func migrateConfigToV8(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(env, "collaborators", map[string]interface{}{})
+		}
+	}
+
+	return nil
+}
+
+// migrateConfigToV9 adds the members of the installation.
+// No members means that everything is permitted.
+func migrateConfigToV9(config rawConfigJSON) error {
+	setRawConfigDefault(config, "members", map[string]interface{}{})
+
+	return nil
+}
+
+// migrateConfigToV10 adds the namespaces and places
+// the existing clusters into the default namespace
+func migrateConfigToV10(config rawConfigJSON) error {
+	setRawConfigDefault(config, "namespaces", map[string]interface{}{})
+
+	namespaces := getRawConfigObject(config, "namespaces")
+
+	setRawConfigDefault(namespaces, DefaultNamespaceName, map[string]interface{}{
+		"name": DefaultNamespaceName,
+		"quotas": map[string]interface{}{
+			"max_envs_per_cluster":   0,
+			"max_envs_per_owner":     0,
+			"allowed_instance_types": []interface{}{},
+			"max_monthly_spend":      0,
+		},
+		"members":              map[string]interface{}{},
+		"created_at_timestamp": 0,
+	})
+
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		setRawConfigDefault(cluster, "namespace", DefaultNamespaceName)
+	}
+
+	return nil
+}
+
+// migrateConfigToV11 adds the time of the last status change of
+// clusters and envs. The creation time is the best known value.
+func migrateConfigToV11(config rawConfigJSON) error {
+	for _, cluster := range getRawConfigObjects(config, "clusters") {
+		setRawConfigDefault(
+			cluster,
+			"status_updated_at_timestamp",
+			getRawConfigTimestamp(cluster, "created_at_timestamp"),
+		)
+
+		for _, env := range getRawConfigObjects(cluster, "envs") {
+			setRawConfigDefault(
+				env,
+				"status_updated_at_timestamp",
+				getRawConfigTimestamp(env, "created_at_timestamp"),
+			)
+		}
+	}
+
+	return nil
+}
+
+// getRawConfigTimestamp returns the timestamp as decoded
+// (e.g. json.Number) to keep its precision. Zero is
+// returned when the timestamp is missing or invalid.
+func getRawConfigTimestamp(parent rawConfigJSON, key string) interface{} {
+	switch timestamp := parent[key].(type) {
+	case json.Number, float64:
+		return timestamp
+	default:
+		return 0
+	}
+}
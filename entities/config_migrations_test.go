@@ -0,0 +1,687 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestConfigMigrationsRegistry(t *testing.T) {
+	for migrationIndex, migration := range configMigrations {
+		expectedSchemaVersion := migrationIndex + 1
+
+		if migration.ToSchemaVersion != expectedSchemaVersion {
+			t.Fatalf(
+				"expected migration %d to target schema version %d, got %d",
+				migrationIndex,
+				expectedSchemaVersion,
+				migration.ToSchemaVersion,
+			)
+		}
+	}
+
+	lastMigration := configMigrations[len(configMigrations)-1]
+
+	if lastMigration.ToSchemaVersion != ConfigSchemaVersion {
+		t.Fatalf(
+			"expected last migration to target schema version %d, got %d",
+			ConfigSchemaVersion,
+			lastMigration.ToSchemaVersion,
+		)
+	}
+}
+
+func TestMigrateConfigToV1(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":          "env",
+						"ssh_host_keys": nil,
+						"runtimes": map[string]interface{}{
+							"go": "latest",
+						},
+					},
+				},
+			},
+
+			"empty": map[string]interface{}{
+				"envs": nil,
+			},
+		},
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":          "env",
+						"ssh_host_keys": []interface{}{},
+						"repositories":  []interface{}{},
+						"runtimes": map[string]interface{}{
+							"go": "latest",
+						},
+						"served_ports": map[string]interface{}{},
+						"secrets":      map[string]interface{}{},
+					},
+				},
+			},
+
+			"empty": map[string]interface{}{
+				"envs": map[string]interface{}{},
+			},
+		},
+	}
+
+	err := migrateConfigToV1(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+
+	config = rawConfigJSON{}
+
+	err = migrateConfigToV1(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(rawConfigJSON{"clusters": map[string]interface{}{}}, config) {
+		t.Fatalf("expected clusters to be set, got '%+v'", config)
+	}
+}
+
+func TestMigrateConfigToV2(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{},
+	}
+
+	err := migrateConfigToV2(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters":  map[string]interface{}{},
+		"audit_log": []interface{}{},
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV3(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name": "env",
+					},
+
+					"labeled_env": map[string]interface{}{
+						"name": "labeled_env",
+						"labels": map[string]interface{}{
+							"team": "api",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"labels": map[string]interface{}{},
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":   "env",
+						"labels": map[string]interface{}{},
+					},
+
+					"labeled_env": map[string]interface{}{
+						"name": "labeled_env",
+						"labels": map[string]interface{}{
+							"team": "api",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV3(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV4(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name": "env",
+					},
+				},
+			},
+		},
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":                 "env",
+						"expires_at_timestamp": 0,
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV4(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV5(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name": "env",
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV5(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	cluster := getRawConfigObject(getRawConfigObject(config, "clusters"), "default")
+	env := getRawConfigObject(getRawConfigObject(cluster, "envs"), "env")
+
+	for _, object := range []rawConfigJSON{cluster, env} {
+		idlePolicy, hasIdlePolicy := object["idle_policy"]
+
+		if !hasIdlePolicy || idlePolicy != nil {
+			t.Fatalf("expected idle policy to be set to null, got '%+v'", object)
+		}
+	}
+}
+
+func TestMigrateConfigToV6(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name": "env",
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV6(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if getRawConfigObject(config, "quotas") == nil {
+		t.Fatalf("expected quotas to be set, got '%+v'", config)
+	}
+
+	cluster := getRawConfigObject(getRawConfigObject(config, "clusters"), "default")
+	env := getRawConfigObject(getRawConfigObject(cluster, "envs"), "env")
+
+	if env["owner"] != "" {
+		t.Fatalf("expected owner to be empty, got '%+v'", env["owner"])
+	}
+
+	var migratedConfig *Config
+	configJSON, _ := json.Marshal(config)
+	err = json.Unmarshal(configJSON, &migratedConfig)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(NewConfigQuotas(), migratedConfig.Quotas) {
+		t.Fatalf(
+			"expected quotas to equal '%+v', got '%+v'",
+			NewConfigQuotas(),
+			migratedConfig.Quotas,
+		)
+	}
+}
+
+func TestMigrateConfigToV7(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{},
+			},
+		},
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs":      map[string]interface{}{},
+				"snapshots": map[string]interface{}{},
+			},
+		},
+	}
+
+	err := migrateConfigToV7(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV8(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name": "env",
+					},
+				},
+			},
+		},
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":          "env",
+						"collaborators": map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV8(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV9(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{},
+	}
+
+	err := migrateConfigToV9(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{},
+		"members":  map[string]interface{}{},
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV10(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"name": "default",
+			},
+		},
+	}
+
+	err := migrateConfigToV10(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedConfig := rawConfigJSON{
+		"namespaces": map[string]interface{}{
+			"default": map[string]interface{}{
+				"name": "default",
+				"quotas": map[string]interface{}{
+					"max_envs_per_cluster":   0,
+					"max_envs_per_owner":     0,
+					"allowed_instance_types": []interface{}{},
+					"max_monthly_spend":      0,
+				},
+				"members":              map[string]interface{}{},
+				"created_at_timestamp": 0,
+			},
+		},
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"name":      "default",
+				"namespace": "default",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigToV11(t *testing.T) {
+	config := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"name":                 "default",
+				"created_at_timestamp": float64(10),
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":                 "env",
+						"created_at_timestamp": float64(20),
+					},
+				},
+			},
+		},
+	}
+
+	err := migrateConfigToV11(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedConfig := rawConfigJSON{
+		"clusters": map[string]interface{}{
+			"default": map[string]interface{}{
+				"name":                        "default",
+				"created_at_timestamp":        float64(10),
+				"status_updated_at_timestamp": float64(10),
+				"envs": map[string]interface{}{
+					"env": map[string]interface{}{
+						"name":                        "env",
+						"created_at_timestamp":        float64(20),
+						"status_updated_at_timestamp": float64(20),
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedConfig, config) {
+		t.Fatalf(
+			"expected migrated config to equal '%+v', got '%+v'",
+			expectedConfig,
+			config,
+		)
+	}
+}
+
+func TestMigrateConfigJSON(t *testing.T) {
+	testCases := []struct {
+		test                  string
+		configJSON            string
+		expectedSchemaVersion int
+		expectedUnchanged     bool
+	}{
+		{
+			test:                  "with unversioned config",
+			configJSON:            `{"id": "id", "clusters": null}`,
+			expectedSchemaVersion: ConfigSchemaVersion,
+		},
+
+		{
+			test: "with current config",
+			configJSON: fmt.Sprintf(
+				`{"id": "id", "schema_version": %d, "clusters": {}, "audit_log": []}`,
+				ConfigSchemaVersion,
+			),
+			expectedSchemaVersion: ConfigSchemaVersion,
+			expectedUnchanged:     true,
+		},
+
+		{
+			test:                  "with newer config",
+			configJSON:            `{"id": "id", "schema_version": 1000, "clusters": {}}`,
+			expectedSchemaVersion: 1000,
+			expectedUnchanged:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			migratedConfigJSON, err := MigrateConfigJSON([]byte(tc.configJSON))
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedUnchanged && string(migratedConfigJSON) != tc.configJSON {
+				t.Fatalf(
+					"expected config to be unchanged, got '%s'",
+					migratedConfigJSON,
+				)
+			}
+
+			config, err := decodeRawConfigJSON(migratedConfigJSON)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			schemaVersion := getRawConfigSchemaVersion(config)
+
+			if schemaVersion != tc.expectedSchemaVersion {
+				t.Fatalf(
+					"expected schema version to equal %d, got %d",
+					tc.expectedSchemaVersion,
+					schemaVersion,
+				)
+			}
+		})
+	}
+
+	_, err := MigrateConfigJSON([]byte("invalid_json"))
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+
+	_, err = MigrateConfigJSON([]byte(`{"id": "id"} {}`))
+
+	if !errors.Is(err, ErrConfigTrailingData) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrConfigTrailingData,
+			err,
+		)
+	}
+}
+
+func TestMigrateConfigJSONKeepsLargeIntegers(t *testing.T) {
+	// Not representable as float64
+	var largeInt int64 = 9007199254740993
+
+	configJSON := fmt.Sprintf(
+		`{"id": "id", "clusters": {"default": {"name": "default", "created_at_timestamp": %d, "envs": {}}}}`,
+		largeInt,
+	)
+
+	migratedConfigJSON, err := MigrateConfigJSON([]byte(configJSON))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	var config struct {
+		Clusters map[string]struct {
+			CreatedAtTimestamp       int64 `json:"created_at_timestamp"`
+			StatusUpdatedAtTimestamp int64 `json:"status_updated_at_timestamp"`
+		} `json:"clusters"`
+	}
+
+	err = json.Unmarshal(migratedConfigJSON, &config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	cluster := config.Clusters["default"]
+
+	if cluster.CreatedAtTimestamp != largeInt {
+		t.Fatalf(
+			"expected created at timestamp to equal '%d', got '%d'",
+			largeInt,
+			cluster.CreatedAtTimestamp,
+		)
+	}
+
+	if cluster.StatusUpdatedAtTimestamp != largeInt {
+		t.Fatalf(
+			"expected status updated at timestamp to equal '%d', got '%d'",
+			largeInt,
+			cluster.StatusUpdatedAtTimestamp,
+		)
+	}
+}
+
+func TestConfigUnmarshalJSONMigratesConfig(t *testing.T) {
+	configJSON := `{
+		"id": "id",
+		"clusters": {
+			"default": {
+				"name": "default",
+				"envs": {
+					"env": {"name": "env", "served_ports": null}
+				}
+			}
+		}
+	}`
+
+	var config *Config
+	err := json.Unmarshal([]byte(configJSON), &config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if config.SchemaVersion != ConfigSchemaVersion {
+		t.Fatalf(
+			"expected schema version to equal %d, got %d",
+			ConfigSchemaVersion,
+			config.SchemaVersion,
+		)
+	}
+
+	env, err := config.GetEnv("default", "env")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.ServedPorts == nil {
+		t.Fatalf("expected served ports to be migrated")
+	}
+}
+
+func TestConfigMarshalJSONRefusesNewerConfigs(t *testing.T) {
+	config := NewConfig()
+
+	_, err := json.Marshal(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	config.SchemaVersion = ConfigSchemaVersion + 1
+
+	_, err = json.Marshal(config)
+
+	if err == nil || !errors.As(err, &ErrConfigSchemaVersionTooNew{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrConfigSchemaVersionTooNew{},
+			err,
+		)
+	}
+}
@@ -0,0 +1,214 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const (
+	DefaultNamespaceName = "default"
+	// Namespaces could be named after GitHub organizations
+	NamespaceNameRegExp    = `^[a-z0-9]+(-[a-z0-9]+)*$`
+	NamespaceNameMaxLength = GitHubUsernameMaxLength
+)
+
+// Namespace groups the clusters of a team (or a GitHub
+// organization) sharing an installation. Namespace quotas and
+// members apply in addition to the installation-wide ones.
+type Namespace struct {
+	Name               string        `json:"name"`
+	Quotas             ConfigQuotas  `json:"quotas"`
+	Members            ConfigMembers `json:"members"`
+	CreatedAtTimestamp int64         `json:"created_at_timestamp"`
+}
+
+func NewNamespace(namespaceName string) *Namespace {
+	return &Namespace{
+		Name:               namespaceName,
+		Quotas:             NewConfigQuotas(),
+		Members:            ConfigMembers{},
+		CreatedAtTimestamp: time.Now().Unix(),
+	}
+}
+
+func CheckNamespaceNameValidity(namespaceName string) error {
+	validNamespaceName := govalidator.Matches(
+		namespaceName,
+		NamespaceNameRegExp,
+	)
+
+	if !validNamespaceName || len(namespaceName) > NamespaceNameMaxLength {
+		return ErrInvalidNamespaceName{
+			NamespaceName:          namespaceName,
+			NamespaceNameRegExp:    NamespaceNameRegExp,
+			NamespaceNameMaxLength: NamespaceNameMaxLength,
+		}
+	}
+
+	return nil
+}
+
+func (n *Namespace) HasMembers() bool {
+	return len(n.Members) > 0
+}
+
+func (n *Namespace) SetMember(member *ConfigMember) error {
+	members, err := n.Members.with(member)
+
+	if err != nil {
+		return err
+	}
+
+	n.Members = members
+
+	return nil
+}
+
+func (n *Namespace) RemoveMember(username string) error {
+	members, err := n.Members.without(username)
+
+	if err != nil {
+		return err
+	}
+
+	n.Members = members
+
+	return nil
+}
+
+func (c *Config) NamespaceExists(namespaceName string) bool {
+	_, namespaceExists := c.Namespaces[namespaceName]
+	return namespaceExists
+}
+
+func (c *Config) GetNamespace(namespaceName string) (*Namespace, error) {
+	if !c.NamespaceExists(namespaceName) {
+		return nil, ErrNamespaceNotExists{
+			NamespaceName: namespaceName,
+		}
+	}
+
+	return c.Namespaces[namespaceName], nil
+}
+
+func (c *Config) SetNamespace(namespace *Namespace) {
+	if c.Namespaces == nil {
+		c.Namespaces = map[string]*Namespace{}
+	}
+
+	c.Namespaces[namespace.Name] = namespace
+}
+
+// RemoveNamespace returns an error if the namespace still contains
+// clusters. The default namespace could not be removed.
+func (c *Config) RemoveNamespace(namespaceName string) error {
+	if !c.NamespaceExists(namespaceName) {
+		return ErrNamespaceNotExists{
+			NamespaceName: namespaceName,
+		}
+	}
+
+	if namespaceName == DefaultNamespaceName ||
+		len(c.GetClustersInNamespace(namespaceName)) > 0 {
+
+		return ErrNamespaceNotEmpty{
+			NamespaceName: namespaceName,
+		}
+	}
+
+	delete(c.Namespaces, namespaceName)
+
+	return nil
+}
+
+// GetSortedNamespaces returns the namespaces sorted by name
+func (c *Config) GetSortedNamespaces() []*Namespace {
+	namespaces := []*Namespace{}
+
+	for _, namespace := range c.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+
+	return namespaces
+}
+
+// GetClustersInNamespace returns the
+// clusters of the namespace sorted by name
+func (c *Config) GetClustersInNamespace(namespaceName string) []*Cluster {
+	clusters := []*Cluster{}
+
+	for _, cluster := range c.GetSortedClusters() {
+		if cluster.Namespace == namespaceName {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters
+}
+
+// GetClusterNamespace returns the namespace of the cluster
+// or nil if the cluster (or its namespace) doesn't exist
+func (c *Config) GetClusterNamespace(clusterName string) *Namespace {
+	cluster, err := c.GetCluster(clusterName)
+
+	if err != nil {
+		return nil
+	}
+
+	namespace, err := c.GetNamespace(cluster.Namespace)
+
+	if err != nil {
+		return nil
+	}
+
+	return namespace
+}
+
+// GetClusterNamespaceName returns the name of the namespace of the
+// cluster. Clusters that don't exist yet are created in the default one.
+func (c *Config) GetClusterNamespaceName(clusterName string) string {
+	cluster, err := c.GetCluster(clusterName)
+
+	if err != nil || len(cluster.Namespace) == 0 {
+		return DefaultNamespaceName
+	}
+
+	return cluster.Namespace
+}
+
+// CheckNamespacePermission returns ErrPermissionDenied if the
+// actor is granted the permission neither by the installation
+// members nor by the namespace ones. Like for installations,
+// everything is permitted when no members are set at all.
+func (c *Config) CheckNamespacePermission(
+	namespaceName string,
+	actor string,
+	permission Permission,
+) error {
+
+	namespace, err := c.GetNamespace(namespaceName)
+
+	if err != nil {
+		return err
+	}
+
+	if !c.IsShared() || c.Members.grant(actor, permission) {
+		return nil
+	}
+
+	if namespace.HasMembers() && namespace.Members.grant(actor, permission) {
+		return nil
+	}
+
+	return ErrPermissionDenied{
+		Actor:      actor,
+		Permission: permission,
+		Namespace:  namespaceName,
+	}
+}
@@ -0,0 +1,304 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func buildTestNamespacedConfig() *Config {
+	config := NewConfig()
+	config.SetNamespace(NewNamespace("team-a"))
+
+	config.Clusters["cluster-a"] = &Cluster{
+		Name:      "cluster-a",
+		Namespace: "team-a",
+		Envs: map[string]*Env{
+			"env1": {
+				Name:         "env1",
+				Owner:        "john",
+				InstanceType: "t2.medium",
+				Status:       EnvStatusCreated,
+			},
+		},
+	}
+
+	config.Clusters["cluster-b"] = &Cluster{
+		Name:      "cluster-b",
+		Namespace: DefaultNamespaceName,
+		Envs: map[string]*Env{
+			"env2": {
+				Name:         "env2",
+				Owner:        "john",
+				InstanceType: "t2.medium",
+				Status:       EnvStatusCreated,
+			},
+		},
+	}
+
+	return config
+}
+
+func TestCheckNamespaceNameValidity(t *testing.T) {
+	if err := CheckNamespaceNameValidity("team-a"); err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err := CheckNamespaceNameValidity("Team_A")
+
+	if !errors.As(err, &ErrInvalidNamespaceName{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidNamespaceName{},
+			err,
+		)
+	}
+}
+
+func TestConfigNamespaces(t *testing.T) {
+	config := buildTestNamespacedConfig()
+
+	clusters := config.GetClustersInNamespace("team-a")
+
+	if len(clusters) != 1 || clusters[0].Name != "cluster-a" {
+		t.Fatalf("expected only 'cluster-a' in namespace, got '%+v'", clusters)
+	}
+
+	namespace := config.GetClusterNamespace("cluster-a")
+
+	if namespace == nil || namespace.Name != "team-a" {
+		t.Fatalf("expected cluster namespace to equal 'team-a', got '%+v'", namespace)
+	}
+
+	namespaceName := config.GetClusterNamespaceName("cluster-a")
+
+	if namespaceName != "team-a" {
+		t.Fatalf("expected cluster namespace name to equal 'team-a', got '%s'", namespaceName)
+	}
+
+	namespaceName = config.GetClusterNamespaceName("unknown-cluster")
+
+	if namespaceName != DefaultNamespaceName {
+		t.Fatalf(
+			"expected cluster namespace name to equal '%s', got '%s'",
+			DefaultNamespaceName,
+			namespaceName,
+		)
+	}
+
+	err := config.RemoveNamespace("team-a")
+
+	if !errors.As(err, &ErrNamespaceNotEmpty{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNamespaceNotEmpty{},
+			err,
+		)
+	}
+
+	err = config.RemoveNamespace(DefaultNamespaceName)
+
+	if !errors.As(err, &ErrNamespaceNotEmpty{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNamespaceNotEmpty{},
+			err,
+		)
+	}
+
+	delete(config.Clusters, "cluster-a")
+
+	err = config.RemoveNamespace("team-a")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.RemoveNamespace("team-a")
+
+	if !errors.As(err, &ErrNamespaceNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNamespaceNotExists{},
+			err,
+		)
+	}
+}
+
+func TestConfigCheckNamespacePermission(t *testing.T) {
+	config := buildTestNamespacedConfig()
+
+	err := config.CheckNamespacePermission("team-a", "anyone", PermissionManageEnvs)
+
+	if err != nil {
+		t.Fatalf("expected everything to be permitted without members, got '%+v'", err)
+	}
+
+	namespace, _ := config.GetNamespace("team-a")
+
+	err = namespace.SetMember(NewConfigMember("jane", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("alice", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	testCases := []struct {
+		test          string
+		namespaceName string
+		actor         string
+		expectedError bool
+	}{
+		{
+			test:          "with installation admin",
+			namespaceName: "team-a",
+			actor:         "alice",
+		},
+
+		{
+			test:          "with namespace admin",
+			namespaceName: "team-a",
+			actor:         "jane",
+		},
+
+		{
+			test:          "with namespace admin in other namespace",
+			namespaceName: DefaultNamespaceName,
+			actor:         "jane",
+			expectedError: true,
+		},
+
+		{
+			test:          "with unknown actor",
+			namespaceName: "team-a",
+			actor:         "mallory",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := config.CheckNamespacePermission(
+				tc.namespaceName,
+				tc.actor,
+				PermissionManageInstallation,
+			)
+
+			if !tc.expectedError {
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				return
+			}
+
+			var permissionDeniedErr ErrPermissionDenied
+
+			if !errors.As(err, &permissionDeniedErr) ||
+				permissionDeniedErr.Namespace != tc.namespaceName {
+
+				t.Fatalf(
+					"expected permission denied error in namespace '%s', got '%+v'",
+					tc.namespaceName,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigPermissionsWithNamespaceMembersOnly(t *testing.T) {
+	config := buildTestNamespacedConfig()
+	namespace, _ := config.GetNamespace("team-a")
+
+	err := namespace.SetMember(NewConfigMember("jane", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !config.IsShared() {
+		t.Fatalf("expected installation to be shared")
+	}
+
+	err = config.CheckPermission("jane", PermissionManageInstallation)
+
+	if !errors.As(err, &ErrPermissionDenied{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrPermissionDenied{},
+			err,
+		)
+	}
+
+	err = config.CheckNamespacePermission(
+		DefaultNamespaceName,
+		"anyone",
+		PermissionManageEnvs,
+	)
+
+	if !errors.As(err, &ErrPermissionDenied{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrPermissionDenied{},
+			err,
+		)
+	}
+
+	err = config.SetMember(NewConfigMember("alice", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.RemoveMember("alice")
+
+	if !errors.Is(err, ErrNoConfigAdmin) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNoConfigAdmin,
+			err,
+		)
+	}
+}
+
+func TestConfigCheckNamespaceQuotas(t *testing.T) {
+	config := buildTestNamespacedConfig()
+	namespace, _ := config.GetNamespace("team-a")
+	namespace.Quotas.MaxEnvsPerOwner = 1
+
+	now := time.Unix(100000, 0)
+
+	// "john" owns an env in both namespaces
+	// but only one in the "team-a" namespace
+	err := config.CheckQuotas(QuotasCheckRequest{
+		ClusterName:  "cluster-a",
+		Owner:        "john",
+		InstanceType: "t2.medium",
+	}, nil, now)
+
+	var quotaExceededErr ErrQuotaExceeded
+
+	if !errors.As(err, &quotaExceededErr) ||
+		quotaExceededErr.Namespace != "team-a" ||
+		quotaExceededErr.Current != 1 {
+
+		t.Fatalf("expected quota to be exceeded in namespace 'team-a', got '%+v'", err)
+	}
+
+	// Namespace quotas don't apply to other namespaces
+	err = config.CheckQuotas(QuotasCheckRequest{
+		ClusterName:  "cluster-b",
+		Owner:        "john",
+		InstanceType: "t2.medium",
+	}, nil, now)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
@@ -0,0 +1,262 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+type Permission string
+
+const (
+	// PermissionRead allows to look up the config
+	// (e.g. to list envs or to estimate costs)
+	PermissionRead Permission = "read"
+	// PermissionManageEnvs allows to create,
+	// update and remove envs
+	PermissionManageEnvs Permission = "manage_envs"
+	// PermissionManageInstallation allows to manage the
+	// clusters, the quotas, the members and to uninstall Eleven
+	PermissionManageInstallation Permission = "manage_installation"
+)
+
+type MemberRole string
+
+const (
+	MemberRoleAdmin  MemberRole = "admin"
+	MemberRoleMember MemberRole = "member"
+	MemberRoleViewer MemberRole = "viewer"
+)
+
+var MemberRoles = []MemberRole{
+	MemberRoleAdmin,
+	MemberRoleMember,
+	MemberRoleViewer,
+}
+
+var memberRolePermissions = map[MemberRole][]Permission{
+	MemberRoleAdmin: {
+		PermissionRead,
+		PermissionManageEnvs,
+		PermissionManageInstallation,
+	},
+
+	MemberRoleMember: {
+		PermissionRead,
+		PermissionManageEnvs,
+	},
+
+	MemberRoleViewer: {
+		PermissionRead,
+	},
+}
+
+// ConfigMember maps a GitHub identity
+// to a role in the Eleven installation
+type ConfigMember struct {
+	Username           string     `json:"username"`
+	Role               MemberRole `json:"role"`
+	CreatedAtTimestamp int64      `json:"created_at_timestamp"`
+}
+
+// ConfigMembers are indexed by lowercased
+// username given that GitHub usernames
+// are case-insensitive.
+type ConfigMembers map[string]*ConfigMember
+
+func NewConfigMember(username string, role MemberRole) *ConfigMember {
+	return &ConfigMember{
+		Username:           username,
+		Role:               role,
+		CreatedAtTimestamp: time.Now().Unix(),
+	}
+}
+
+func ParseMemberRole(role string) (MemberRole, error) {
+	for _, validRole := range MemberRoles {
+		if string(validRole) == role {
+			return validRole, nil
+		}
+	}
+
+	return "", ErrInvalidMemberRole{
+		Role:       role,
+		ValidRoles: MemberRoles,
+	}
+}
+
+func (r MemberRole) HasPermission(permission Permission) bool {
+	for _, rolePermission := range memberRolePermissions[r] {
+		if rolePermission == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasMembers returns true if the installation
+// has members. See IsShared for namespaces.
+func (c *Config) HasMembers() bool {
+	return len(c.Members) > 0
+}
+
+// IsShared returns false for installations that were never
+// shared, neither installation-wide nor in a namespace.
+// Everything is permitted in that case.
+func (c *Config) IsShared() bool {
+	if c.HasMembers() {
+		return true
+	}
+
+	for _, namespace := range c.Namespaces {
+		if namespace.HasMembers() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Config) MemberExists(username string) bool {
+	_, memberExists := c.Members[strings.ToLower(username)]
+	return memberExists
+}
+
+func (c *Config) GetMember(username string) (*ConfigMember, error) {
+	if !c.MemberExists(username) {
+		return nil, ErrConfigMemberNotExists{
+			Username: username,
+		}
+	}
+
+	return c.Members[strings.ToLower(username)], nil
+}
+
+// SetMember adds or updates the passed member. An error is
+// returned if the installation would end up without admin.
+func (c *Config) SetMember(member *ConfigMember) error {
+	members, err := c.Members.with(member)
+
+	if err != nil {
+		return err
+	}
+
+	c.Members = members
+
+	return nil
+}
+
+// RemoveMember removes the passed member. An error is returned
+// if the remaining members would end up without admin or if
+// the last member is removed while namespaces have members.
+func (c *Config) RemoveMember(username string) error {
+	members, err := c.Members.without(username)
+
+	if err != nil {
+		return err
+	}
+
+	if len(members) == 0 {
+		for _, namespace := range c.Namespaces {
+			if namespace.HasMembers() {
+				return ErrNoConfigAdmin
+			}
+		}
+	}
+
+	c.Members = members
+
+	return nil
+}
+
+func (c *Config) GetSortedMembers() []*ConfigMember {
+	return c.Members.GetSorted()
+}
+
+// GetSorted returns the members sorted by username
+func (c ConfigMembers) GetSorted() []*ConfigMember {
+	members := []*ConfigMember{}
+
+	for _, member := range c {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return strings.ToLower(members[i].Username) <
+			strings.ToLower(members[j].Username)
+	})
+
+	return members
+}
+
+// CheckPermission returns ErrPermissionDenied if the actor
+// (a GitHub username) is not granted the passed permission
+// by the installation members. Namespace members are never
+// granted installation-wide permissions.
+func (c *Config) CheckPermission(actor string, permission Permission) error {
+	if !c.IsShared() || c.Members.grant(actor, permission) {
+		return nil
+	}
+
+	return ErrPermissionDenied{
+		Actor:      actor,
+		Permission: permission,
+	}
+}
+
+func (c ConfigMembers) grant(actor string, permission Permission) bool {
+	member, memberExists := c[strings.ToLower(actor)]
+
+	return memberExists && member.Role.HasPermission(permission)
+}
+
+// with returns a copy of the members with the passed one added
+func (c ConfigMembers) with(member *ConfigMember) (ConfigMembers, error) {
+	members := c.clone()
+	members[strings.ToLower(member.Username)] = member
+
+	if !members.hasAdmin() {
+		return nil, ErrNoConfigAdmin
+	}
+
+	return members, nil
+}
+
+// without returns a copy of the members with the passed one removed
+func (c ConfigMembers) without(username string) (ConfigMembers, error) {
+	if _, memberExists := c[strings.ToLower(username)]; !memberExists {
+		return nil, ErrConfigMemberNotExists{
+			Username: username,
+		}
+	}
+
+	members := c.clone()
+	delete(members, strings.ToLower(username))
+
+	if len(members) > 0 && !members.hasAdmin() {
+		return nil, ErrNoConfigAdmin
+	}
+
+	return members, nil
+}
+
+func (c ConfigMembers) clone() ConfigMembers {
+	members := ConfigMembers{}
+
+	for username, member := range c {
+		members[username] = member
+	}
+
+	return members
+}
+
+func (c ConfigMembers) hasAdmin() bool {
+	for _, member := range c {
+		if member.Role == MemberRoleAdmin {
+			return true
+		}
+	}
+
+	return false
+}
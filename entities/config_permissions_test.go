@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigCheckPermission(t *testing.T) {
+	config := NewConfig()
+
+	err := config.CheckPermission("anyone", PermissionManageInstallation)
+
+	if err != nil {
+		t.Fatalf("expected everything to be permitted without members, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("Alice", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("bob", MemberRoleMember))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("carol", MemberRoleViewer))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	testCases := []struct {
+		test          string
+		actor         string
+		permission    Permission
+		expectedError bool
+	}{
+		{
+			test:       "with admin managing installation",
+			actor:      "alice",
+			permission: PermissionManageInstallation,
+		},
+
+		{
+			test:       "with member managing envs",
+			actor:      "Bob",
+			permission: PermissionManageEnvs,
+		},
+
+		{
+			test:          "with member managing installation",
+			actor:         "bob",
+			permission:    PermissionManageInstallation,
+			expectedError: true,
+		},
+
+		{
+			test:       "with viewer reading",
+			actor:      "carol",
+			permission: PermissionRead,
+		},
+
+		{
+			test:          "with viewer managing envs",
+			actor:         "carol",
+			permission:    PermissionManageEnvs,
+			expectedError: true,
+		},
+
+		{
+			test:          "with unknown actor",
+			actor:         "mallory",
+			permission:    PermissionRead,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := config.CheckPermission(tc.actor, tc.permission)
+
+			if !tc.expectedError {
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				return
+			}
+
+			var permissionDeniedErr ErrPermissionDenied
+
+			if !errors.As(err, &permissionDeniedErr) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrPermissionDenied{},
+					err,
+				)
+			}
+
+			if permissionDeniedErr.Actor != tc.actor ||
+				permissionDeniedErr.Permission != tc.permission {
+
+				t.Fatalf(
+					"expected error to carry actor '%s' and permission '%s', got '%+v'",
+					tc.actor,
+					tc.permission,
+					permissionDeniedErr,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigMembersKeepAdmin(t *testing.T) {
+	config := NewConfig()
+
+	err := config.SetMember(NewConfigMember("bob", MemberRoleMember))
+
+	if !errors.Is(err, ErrNoConfigAdmin) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNoConfigAdmin,
+			err,
+		)
+	}
+
+	if config.HasMembers() {
+		t.Fatalf("expected member to not be added")
+	}
+
+	err = config.SetMember(NewConfigMember("alice", MemberRoleAdmin))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("bob", MemberRoleMember))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.SetMember(NewConfigMember("Alice", MemberRoleViewer))
+
+	if !errors.Is(err, ErrNoConfigAdmin) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNoConfigAdmin,
+			err,
+		)
+	}
+
+	err = config.RemoveMember("alice")
+
+	if !errors.Is(err, ErrNoConfigAdmin) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrNoConfigAdmin,
+			err,
+		)
+	}
+
+	err = config.RemoveMember("bob")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	// Removing the last member makes
+	// the installation unshared again
+	err = config.RemoveMember("alice")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = config.RemoveMember("alice")
+
+	if !errors.As(err, &ErrConfigMemberNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrConfigMemberNotExists{},
+			err,
+		)
+	}
+}
+
+func TestParseMemberRole(t *testing.T) {
+	role, err := ParseMemberRole("member")
+
+	if err != nil || role != MemberRoleMember {
+		t.Fatalf("expected role '%s' without error, got '%s' and '%+v'", MemberRoleMember, role, err)
+	}
+
+	_, err = ParseMemberRole("owner")
+
+	if !errors.As(err, &ErrInvalidMemberRole{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidMemberRole{},
+			err,
+		)
+	}
+}
@@ -0,0 +1,407 @@
+package entities
+
+import "time"
+
+type QuotaType string
+
+const (
+	QuotaTypeMaxEnvsPerCluster QuotaType = "max_envs_per_cluster"
+	QuotaTypeMaxEnvsPerOwner   QuotaType = "max_envs_per_owner"
+	QuotaTypeMaxMonthlySpend   QuotaType = "max_monthly_spend"
+)
+
+// ConfigQuotas are enforced before creating or resizing
+// envs. Zero values (and empty lists) mean unlimited.
+type ConfigQuotas struct {
+	MaxEnvsPerCluster    int      `json:"max_envs_per_cluster"`
+	MaxEnvsPerOwner      int      `json:"max_envs_per_owner"`
+	AllowedInstanceTypes []string `json:"allowed_instance_types"`
+	// MaxMonthlySpend is expressed in the
+	// currency of the cloud service price table
+	MaxMonthlySpend float64 `json:"max_monthly_spend"`
+}
+
+func NewConfigQuotas() ConfigQuotas {
+	return ConfigQuotas{
+		AllowedInstanceTypes: []string{},
+	}
+}
+
+func CheckConfigQuotasValidity(quotas ConfigQuotas) error {
+	if quotas.MaxEnvsPerCluster < 0 ||
+		quotas.MaxEnvsPerOwner < 0 ||
+		quotas.MaxMonthlySpend < 0 {
+
+		return ErrInvalidConfigQuotas
+	}
+
+	return nil
+}
+
+func (c ConfigQuotas) HasSpendLimit() bool {
+	return c.MaxMonthlySpend > 0
+}
+
+// QuotasCheckRequest describes an env about to be created or
+// resized. ReplacedEnv is set during resizes to exclude
+// the current state of the env from the usage. MovedEnv is set
+// during moves to exclude the source env, that is removed
+// once moved, from the usage of the clusters it is part of.
+type QuotasCheckRequest struct {
+	ClusterName  string
+	Owner        string
+	InstanceType string
+	ReplacedEnv  *Env
+	MovedEnv     *Env
+}
+
+// CheckQuotas returns an error if the passed request exceeds the
+// installation quotas or the quotas of the cluster's namespace.
+// The price table is only needed when a spend limit is set.
+func (c *Config) CheckQuotas(
+	request QuotasCheckRequest,
+	priceTable *PriceTable,
+	now time.Time,
+) error {
+
+	err := checkQuotas(
+		c.Quotas,
+		"",
+		c.GetSortedClusters(),
+		request,
+		priceTable,
+		now,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Clusters that don't exist yet are
+	// checked against their future namespace
+	namespace, err := c.GetNamespace(
+		c.GetClusterNamespaceName(request.ClusterName),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return checkQuotas(
+		namespace.Quotas,
+		namespace.Name,
+		c.GetClustersInNamespace(namespace.Name),
+		request,
+		priceTable,
+		now,
+	)
+}
+
+// HasSpendLimit returns true if a spend limit applies to
+// the passed cluster, either installation-wide or in its namespace
+func (c *Config) HasSpendLimit(clusterName string) bool {
+	if c.Quotas.HasSpendLimit() {
+		return true
+	}
+
+	namespace, err := c.GetNamespace(
+		c.GetClusterNamespaceName(clusterName),
+	)
+
+	return err == nil && namespace.Quotas.HasSpendLimit()
+}
+
+// CheckClusterNamespaceQuotas returns an error if moving the
+// passed cluster, with all its envs, to the passed namespace
+// exceeds the namespace quotas. The price table is only
+// needed when the namespace has a spend limit.
+func (c *Config) CheckClusterNamespaceQuotas(
+	cluster *Cluster,
+	namespaceName string,
+	priceTable *PriceTable,
+	now time.Time,
+) error {
+
+	namespace, err := c.GetNamespace(namespaceName)
+
+	if err != nil {
+		return err
+	}
+
+	if c.GetClusterNamespaceName(cluster.Name) == namespace.Name {
+		return nil
+	}
+
+	quotas := namespace.Quotas
+	envs := cluster.GetSortedEnvs()
+
+	if len(quotas.AllowedInstanceTypes) > 0 {
+		for _, env := range envs {
+			if containsString(quotas.AllowedInstanceTypes, env.InstanceType) {
+				continue
+			}
+
+			return ErrInstanceTypeNotAllowed{
+				InstanceType:         env.InstanceType,
+				AllowedInstanceTypes: quotas.AllowedInstanceTypes,
+			}
+		}
+	}
+
+	if quotas.MaxEnvsPerCluster > 0 && len(envs) > quotas.MaxEnvsPerCluster {
+		return ErrQuotaExceeded{
+			Quota:     QuotaTypeMaxEnvsPerCluster,
+			Limit:     float64(quotas.MaxEnvsPerCluster),
+			Current:   float64(len(envs)),
+			Namespace: namespace.Name,
+		}
+	}
+
+	namespaceClusters := c.GetClustersInNamespace(namespace.Name)
+	movedClusters := append(
+		append([]*Cluster{}, namespaceClusters...),
+		cluster,
+	)
+
+	if quotas.MaxEnvsPerOwner > 0 {
+		for _, env := range envs {
+			// Envs created before owners were required
+			if len(env.Owner) == 0 {
+				continue
+			}
+
+			nbOfEnvsOwned := countEnvsOwnedBy(movedClusters, env.Owner)
+
+			if nbOfEnvsOwned > quotas.MaxEnvsPerOwner {
+				return ErrQuotaExceeded{
+					Quota:     QuotaTypeMaxEnvsPerOwner,
+					Limit:     float64(quotas.MaxEnvsPerOwner),
+					Current:   float64(countEnvsOwnedBy(namespaceClusters, env.Owner)),
+					Namespace: namespace.Name,
+				}
+			}
+		}
+	}
+
+	if !quotas.HasSpendLimit() {
+		return nil
+	}
+
+	currentMonthlySpend := 0.0
+
+	for _, namespaceCluster := range namespaceClusters {
+		clusterEstimate := priceTable.EstimateClusterCost(namespaceCluster, now)
+		currentMonthlySpend += clusterEstimate.Total.MonthlyCost
+	}
+
+	movedClusterEstimate := priceTable.EstimateClusterCost(cluster, now)
+
+	if currentMonthlySpend+movedClusterEstimate.Total.MonthlyCost > quotas.MaxMonthlySpend {
+		return ErrQuotaExceeded{
+			Quota:     QuotaTypeMaxMonthlySpend,
+			Limit:     quotas.MaxMonthlySpend,
+			Current:   currentMonthlySpend,
+			Namespace: namespace.Name,
+		}
+	}
+
+	return nil
+}
+
+// checkQuotas checks the request against the passed quotas.
+// Usages are computed using the passed clusters only.
+func checkQuotas(
+	quotas ConfigQuotas,
+	namespaceName string,
+	clusters []*Cluster,
+	request QuotasCheckRequest,
+	priceTable *PriceTable,
+	now time.Time,
+) error {
+
+	if len(quotas.AllowedInstanceTypes) > 0 &&
+		!containsString(quotas.AllowedInstanceTypes, request.InstanceType) {
+
+		return ErrInstanceTypeNotAllowed{
+			InstanceType:         request.InstanceType,
+			AllowedInstanceTypes: quotas.AllowedInstanceTypes,
+		}
+	}
+
+	if request.MovedEnv != nil {
+		clusters = excludeEnvFromClusters(clusters, request.MovedEnv)
+	}
+
+	// Resizes don't create envs
+	if request.ReplacedEnv == nil {
+		err := checkEnvsCountQuotas(
+			quotas,
+			namespaceName,
+			clusters,
+			request,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if !quotas.HasSpendLimit() {
+		return nil
+	}
+
+	return checkSpendQuota(
+		quotas,
+		namespaceName,
+		clusters,
+		request,
+		priceTable,
+		now,
+	)
+}
+
+// excludeEnvFromClusters returns copies of the passed
+// clusters without the passed env. Clusters are
+// returned as is if the env is not part of them.
+func excludeEnvFromClusters(clusters []*Cluster, env *Env) []*Cluster {
+	filteredClusters := make([]*Cluster, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		if cluster.Envs[env.Name] != env {
+			filteredClusters = append(filteredClusters, cluster)
+			continue
+		}
+
+		filteredCluster := *cluster
+		filteredCluster.Envs = map[string]*Env{}
+
+		for envName, clusterEnv := range cluster.Envs {
+			if clusterEnv != env {
+				filteredCluster.Envs[envName] = clusterEnv
+			}
+		}
+
+		filteredClusters = append(filteredClusters, &filteredCluster)
+	}
+
+	return filteredClusters
+}
+
+func checkEnvsCountQuotas(
+	quotas ConfigQuotas,
+	namespaceName string,
+	clusters []*Cluster,
+	request QuotasCheckRequest,
+) error {
+
+	if quotas.MaxEnvsPerCluster > 0 {
+		nbOfEnvsInCluster := 0
+
+		for _, cluster := range clusters {
+			if cluster.Name == request.ClusterName {
+				nbOfEnvsInCluster = len(cluster.Envs)
+			}
+		}
+
+		if nbOfEnvsInCluster >= quotas.MaxEnvsPerCluster {
+			return ErrQuotaExceeded{
+				Quota:     QuotaTypeMaxEnvsPerCluster,
+				Limit:     float64(quotas.MaxEnvsPerCluster),
+				Current:   float64(nbOfEnvsInCluster),
+				Namespace: namespaceName,
+			}
+		}
+	}
+
+	if quotas.MaxEnvsPerOwner > 0 {
+		// The quota could be bypassed using anonymous envs
+		if len(request.Owner) == 0 {
+			return ErrQuotaOwnerRequired
+		}
+
+		nbOfEnvsOwned := countEnvsOwnedBy(clusters, request.Owner)
+
+		if nbOfEnvsOwned >= quotas.MaxEnvsPerOwner {
+			return ErrQuotaExceeded{
+				Quota:     QuotaTypeMaxEnvsPerOwner,
+				Limit:     float64(quotas.MaxEnvsPerOwner),
+				Current:   float64(nbOfEnvsOwned),
+				Namespace: namespaceName,
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkSpendQuota(
+	quotas ConfigQuotas,
+	namespaceName string,
+	clusters []*Cluster,
+	request QuotasCheckRequest,
+	priceTable *PriceTable,
+	now time.Time,
+) error {
+
+	currentMonthlySpend := 0.0
+
+	// Envs using unknown instance types are skipped
+	for _, cluster := range clusters {
+		clusterEstimate := priceTable.EstimateClusterCost(cluster, now)
+
+		currentMonthlySpend += clusterEstimate.Total.MonthlyCost
+	}
+
+	projectedEstimate := priceTable.EstimateInstanceTypeCost(
+		request.InstanceType,
+	)
+
+	// The spend quota can't be enforced
+	// without the price of the instance type
+	if projectedEstimate.Unknown {
+		return ErrUnknownInstanceTypePrice{
+			InstanceType: request.InstanceType,
+			Region:       priceTable.Region,
+		}
+	}
+
+	projectedMonthlySpend := currentMonthlySpend + projectedEstimate.MonthlyCost
+
+	if request.ReplacedEnv != nil {
+		replacedEstimate := priceTable.EstimateEnvCost(
+			request.ReplacedEnv,
+			now,
+		)
+
+		projectedMonthlySpend -= replacedEstimate.MonthlyCost
+	}
+
+	if projectedMonthlySpend > quotas.MaxMonthlySpend {
+		return ErrQuotaExceeded{
+			Quota:     QuotaTypeMaxMonthlySpend,
+			Limit:     quotas.MaxMonthlySpend,
+			Current:   currentMonthlySpend,
+			Namespace: namespaceName,
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) CountEnvsOwnedBy(owner string) int {
+	return countEnvsOwnedBy(c.GetSortedClusters(), owner)
+}
+
+func countEnvsOwnedBy(clusters []*Cluster, owner string) int {
+	nbOfEnvsOwned := 0
+
+	for _, cluster := range clusters {
+		for _, env := range cluster.Envs {
+			if env.Owner == owner {
+				nbOfEnvsOwned++
+			}
+		}
+	}
+
+	return nbOfEnvsOwned
+}
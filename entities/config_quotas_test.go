@@ -0,0 +1,477 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigCheckQuotas(t *testing.T) {
+	now := time.Unix(100000, 0)
+	priceTable := buildTestPriceTable()
+
+	buildConfig := func(quotas ConfigQuotas) *Config {
+		config := NewConfig()
+		config.Quotas = quotas
+		config.Clusters[DefaultClusterName] = &Cluster{
+			Name:               DefaultClusterName,
+			CreatedAtTimestamp: now.Unix(),
+			Envs: map[string]*Env{
+				"env1": {
+					Name:               "env1",
+					Owner:              "john",
+					InstanceType:       "t2.medium",
+					Status:             EnvStatusCreated,
+					CreatedAtTimestamp: now.Unix(),
+				},
+			},
+		}
+		return config
+	}
+
+	replacedEnv := &Env{
+		Name:               "env1",
+		InstanceType:       "t2.medium",
+		Status:             EnvStatusCreated,
+		CreatedAtTimestamp: now.Unix(),
+	}
+
+	testCases := []struct {
+		test          string
+		quotas        ConfigQuotas
+		request       QuotasCheckRequest
+		expectedError error
+	}{
+		{
+			test:   "with no quotas",
+			quotas: NewConfigQuotas(),
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				Owner:        "john",
+				InstanceType: "t2.medium",
+			},
+			expectedError: nil,
+		},
+
+		{
+			test: "with not allowed instance type",
+			quotas: ConfigQuotas{
+				AllowedInstanceTypes: []string{"t2.small"},
+			},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+			},
+			expectedError: ErrInstanceTypeNotAllowed{
+				InstanceType:         "t2.medium",
+				AllowedInstanceTypes: []string{"t2.small"},
+			},
+		},
+
+		{
+			test:   "with max envs per cluster exceeded",
+			quotas: ConfigQuotas{MaxEnvsPerCluster: 1},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+			},
+			expectedError: ErrQuotaExceeded{
+				Quota:   QuotaTypeMaxEnvsPerCluster,
+				Limit:   1,
+				Current: 1,
+			},
+		},
+
+		{
+			test:   "with max envs per owner exceeded",
+			quotas: ConfigQuotas{MaxEnvsPerOwner: 1},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				Owner:        "john",
+				InstanceType: "t2.medium",
+			},
+			expectedError: ErrQuotaExceeded{
+				Quota:   QuotaTypeMaxEnvsPerOwner,
+				Limit:   1,
+				Current: 1,
+			},
+		},
+
+		{
+			test:   "with max envs per owner and no owner",
+			quotas: ConfigQuotas{MaxEnvsPerOwner: 1},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+			},
+			expectedError: ErrQuotaOwnerRequired,
+		},
+
+		{
+			test:   "with max envs per owner not exceeded for other owner",
+			quotas: ConfigQuotas{MaxEnvsPerOwner: 1},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				Owner:        "jane",
+				InstanceType: "t2.medium",
+			},
+			expectedError: nil,
+		},
+
+		{
+			test:   "with max envs per cluster and resize",
+			quotas: ConfigQuotas{MaxEnvsPerCluster: 1},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+				ReplacedEnv:  replacedEnv,
+			},
+			expectedError: nil,
+		},
+
+		{
+			// (0.01 + 0.065) * 730 = 54.75 + projected 47.45
+			test:   "with max monthly spend exceeded",
+			quotas: ConfigQuotas{MaxMonthlySpend: 100},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+			},
+			expectedError: ErrQuotaExceeded{
+				Quota:   QuotaTypeMaxMonthlySpend,
+				Limit:   100,
+				Current: 54.75,
+			},
+		},
+
+		{
+			test:   "with max monthly spend and resize",
+			quotas: ConfigQuotas{MaxMonthlySpend: 100},
+			request: QuotasCheckRequest{
+				ClusterName:  DefaultClusterName,
+				InstanceType: "t2.medium",
+				ReplacedEnv:  replacedEnv,
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			config := buildConfig(tc.quotas)
+
+			err := config.CheckQuotas(tc.request, priceTable, now)
+
+			if tc.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error '%+v', got nothing", tc.expectedError)
+			}
+
+			var errQuotaExceeded ErrQuotaExceeded
+
+			if errors.As(err, &errQuotaExceeded) {
+				expectedErr := tc.expectedError.(ErrQuotaExceeded)
+
+				if errQuotaExceeded.Quota != expectedErr.Quota ||
+					errQuotaExceeded.Limit != expectedErr.Limit ||
+					!floatsAlmostEqual(errQuotaExceeded.Current, expectedErr.Current) {
+
+					t.Fatalf(
+						"expected error to equal '%+v', got '%+v'",
+						expectedErr,
+						errQuotaExceeded,
+					)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(tc.expectedError, err) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigCheckQuotasWithNewCluster(t *testing.T) {
+	config := NewConfig()
+	config.Namespaces[DefaultNamespaceName].Quotas = ConfigQuotas{
+		AllowedInstanceTypes: []string{"t2.small"},
+	}
+
+	err := config.CheckQuotas(
+		QuotasCheckRequest{
+			ClusterName:  "new_cluster",
+			Owner:        "john",
+			InstanceType: "t2.medium",
+		},
+		nil,
+		time.Unix(100000, 0),
+	)
+
+	if !errors.As(err, &ErrInstanceTypeNotAllowed{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInstanceTypeNotAllowed{},
+			err,
+		)
+	}
+}
+
+func TestConfigCheckQuotasWithMovedEnv(t *testing.T) {
+	now := time.Unix(100000, 0)
+
+	buildConfig := func(targetNamespaceQuotas ConfigQuotas) (*Config, *Env) {
+		config := NewConfig()
+		config.Quotas = ConfigQuotas{MaxEnvsPerOwner: 1}
+
+		targetNamespace := NewNamespace("target_namespace")
+		targetNamespace.Quotas = targetNamespaceQuotas
+		config.SetNamespace(targetNamespace)
+
+		config.Clusters["source"] = &Cluster{
+			Name: "source",
+			Envs: map[string]*Env{
+				"env1": {
+					Name:         "env1",
+					Owner:        "john",
+					InstanceType: "t2.medium",
+					Status:       EnvStatusCreated,
+				},
+			},
+		}
+
+		config.Clusters["target"] = &Cluster{
+			Name:      "target",
+			Namespace: targetNamespace.Name,
+			Envs: map[string]*Env{
+				"env2": {
+					Name:         "env2",
+					Owner:        "jane",
+					InstanceType: "t2.medium",
+					Status:       EnvStatusCreated,
+				},
+			},
+		}
+
+		return config, config.Clusters["source"].Envs["env1"]
+	}
+
+	testCases := []struct {
+		test                  string
+		targetNamespaceQuotas ConfigQuotas
+		excludeMovedEnv       bool
+		expectedError         error
+	}{
+		{
+			test:            "with max envs per owner reached and no moved env",
+			excludeMovedEnv: false,
+			expectedError: ErrQuotaExceeded{
+				Quota:   QuotaTypeMaxEnvsPerOwner,
+				Limit:   1,
+				Current: 1,
+			},
+		},
+
+		{
+			test:            "with max envs per owner reached by the moved env",
+			excludeMovedEnv: true,
+			expectedError:   nil,
+		},
+
+		{
+			test:                  "with max envs per cluster reached in target namespace",
+			targetNamespaceQuotas: ConfigQuotas{MaxEnvsPerCluster: 1},
+			excludeMovedEnv:       true,
+			expectedError: ErrQuotaExceeded{
+				Quota:     QuotaTypeMaxEnvsPerCluster,
+				Limit:     1,
+				Current:   1,
+				Namespace: "target_namespace",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			config, sourceEnv := buildConfig(tc.targetNamespaceQuotas)
+
+			request := QuotasCheckRequest{
+				ClusterName:  "target",
+				Owner:        sourceEnv.Owner,
+				InstanceType: sourceEnv.InstanceType,
+			}
+
+			if tc.excludeMovedEnv {
+				request.MovedEnv = sourceEnv
+			}
+
+			err := config.CheckQuotas(request, nil, now)
+
+			if !reflect.DeepEqual(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+
+			// The source cluster is left untouched
+			if len(config.Clusters["source"].Envs) != 1 {
+				t.Fatalf("expected source cluster to still contain the moved env")
+			}
+		})
+	}
+}
+
+func TestConfigCheckClusterNamespaceQuotas(t *testing.T) {
+	now := time.Unix(100000, 0)
+	priceTable := buildTestPriceTable()
+
+	buildConfig := func(quotas ConfigQuotas) *Config {
+		config := NewConfig()
+		config.Namespaces["team"] = NewNamespace("team")
+		config.Namespaces["team"].Quotas = quotas
+		config.Clusters["team_cluster"] = &Cluster{
+			Name:               "team_cluster",
+			Namespace:          "team",
+			CreatedAtTimestamp: now.Unix(),
+			Envs: map[string]*Env{
+				"env1": {
+					Name:               "env1",
+					Owner:              "john",
+					InstanceType:       "t2.medium",
+					Status:             EnvStatusCreated,
+					CreatedAtTimestamp: now.Unix(),
+				},
+			},
+		}
+		config.Clusters[DefaultClusterName] = &Cluster{
+			Name:               DefaultClusterName,
+			Namespace:          DefaultNamespaceName,
+			CreatedAtTimestamp: now.Unix(),
+			Envs: map[string]*Env{
+				"env2": {
+					Name:               "env2",
+					Owner:              "john",
+					InstanceType:       "t2.medium",
+					Status:             EnvStatusCreated,
+					CreatedAtTimestamp: now.Unix(),
+				},
+
+				"env3": {
+					Name:               "env3",
+					Owner:              "jane",
+					InstanceType:       "t2.medium",
+					Status:             EnvStatusCreated,
+					CreatedAtTimestamp: now.Unix(),
+				},
+			},
+		}
+		return config
+	}
+
+	testCases := []struct {
+		test          string
+		quotas        ConfigQuotas
+		expectedQuota QuotaType
+	}{
+		{
+			test:   "with no quotas",
+			quotas: NewConfigQuotas(),
+		},
+
+		{
+			test:          "with max envs per cluster exceeded",
+			quotas:        ConfigQuotas{MaxEnvsPerCluster: 1},
+			expectedQuota: QuotaTypeMaxEnvsPerCluster,
+		},
+
+		{
+			test:          "with max envs per owner exceeded",
+			quotas:        ConfigQuotas{MaxEnvsPerOwner: 1},
+			expectedQuota: QuotaTypeMaxEnvsPerOwner,
+		},
+
+		{
+			// (0.01 + 0.065) * 730 + (0.01 + 2 * 0.065) * 730 = 156.95
+			test:          "with max monthly spend exceeded",
+			quotas:        ConfigQuotas{MaxMonthlySpend: 150},
+			expectedQuota: QuotaTypeMaxMonthlySpend,
+		},
+
+		{
+			test:   "with max monthly spend not exceeded",
+			quotas: ConfigQuotas{MaxMonthlySpend: 160},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			config := buildConfig(tc.quotas)
+
+			err := config.CheckClusterNamespaceQuotas(
+				config.Clusters[DefaultClusterName],
+				"team",
+				priceTable,
+				now,
+			)
+
+			if len(tc.expectedQuota) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+
+				return
+			}
+
+			var errQuotaExceeded ErrQuotaExceeded
+
+			if !errors.As(err, &errQuotaExceeded) ||
+				errQuotaExceeded.Quota != tc.expectedQuota {
+
+				t.Fatalf(
+					"expected quota '%s' to be exceeded, got '%+v'",
+					tc.expectedQuota,
+					err,
+				)
+			}
+		})
+	}
+
+	config := buildConfig(ConfigQuotas{
+		AllowedInstanceTypes: []string{"t2.small"},
+	})
+
+	err := config.CheckClusterNamespaceQuotas(
+		config.Clusters[DefaultClusterName],
+		"team",
+		priceTable,
+		now,
+	)
+
+	if !errors.As(err, &ErrInstanceTypeNotAllowed{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInstanceTypeNotAllowed{},
+			err,
+		)
+	}
+}
+
+func floatsAlmostEqual(a, b float64) bool {
+	diff := a - b
+	return diff < 1e-9 && diff > -1e-9
+}
@@ -0,0 +1,85 @@
+package entities
+
+import "time"
+
+// StaleEnv is an env left in a transitional
+// status (e.g. after a failed removal).
+type StaleEnv struct {
+	Cluster *Cluster
+	Env     *Env
+}
+
+// StaleResources lists the envs and clusters that
+// could be safely removed by a cleanup.
+type StaleResources struct {
+	Envs     []StaleEnv
+	Clusters []*Cluster
+}
+
+func (s StaleResources) IsEmpty() bool {
+	return len(s.Envs) == 0 && len(s.Clusters) == 0
+}
+
+func (e *Env) HasTransitionalStatus() bool {
+	return e.Status == EnvStatusCreating || e.Status == EnvStatusRemoving
+}
+
+func (c *Cluster) HasTransitionalStatus() bool {
+	return c.Status == ClusterStatusCreating || c.Status == ClusterStatusRemoving
+}
+
+// FindStaleResources returns the envs and clusters that have
+// been in a transitional status for more than the passed age.
+// The age is computed from the last status change so that
+// in-flight operations (e.g. a removal that just started on
+// an old env) are never reported.
+// A cluster is only considered as stale if all its envs are.
+func (c *Config) FindStaleResources(
+	maxAge time.Duration,
+	now time.Time,
+) StaleResources {
+
+	staleResources := StaleResources{
+		Envs:     []StaleEnv{},
+		Clusters: []*Cluster{},
+	}
+
+	for _, clusterKey := range c.sortedClusterKeys() {
+		cluster := c.Clusters[clusterKey]
+
+		if cluster == nil {
+			continue
+		}
+
+		nbOfStaleEnvsInCluster := 0
+
+		for _, envKey := range sortedEnvKeys(cluster) {
+			env := cluster.Envs[envKey]
+
+			if env == nil ||
+				!env.HasTransitionalStatus() ||
+				!isOlderThan(env.StatusUpdatedAtTimestamp, maxAge, now) {
+
+				continue
+			}
+
+			nbOfStaleEnvsInCluster++
+
+			staleResources.Envs = append(staleResources.Envs, StaleEnv{
+				Cluster: cluster,
+				Env:     env,
+			})
+		}
+
+		if !cluster.HasTransitionalStatus() ||
+			!isOlderThan(cluster.StatusUpdatedAtTimestamp, maxAge, now) ||
+			nbOfStaleEnvsInCluster != len(cluster.Envs) {
+
+			continue
+		}
+
+		staleResources.Clusters = append(staleResources.Clusters, cluster)
+	}
+
+	return staleResources
+}
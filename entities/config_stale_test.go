@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigFindStaleResources(t *testing.T) {
+	now := time.Unix(100000, 0)
+	maxAge := 1 * time.Hour
+
+	recently := now.Add(-1 * time.Minute).Unix()
+	longAgo := now.Add(-2 * maxAge).Unix()
+
+	config := NewConfig()
+
+	config.Clusters["default"] = &Cluster{
+		Name:                     "default",
+		Status:                   ClusterStatusCreated,
+		StatusUpdatedAtTimestamp: longAgo,
+		Envs: map[string]*Env{
+			"created":         {Name: "created", Status: EnvStatusCreated, StatusUpdatedAtTimestamp: longAgo},
+			"creating":        {Name: "creating", Status: EnvStatusCreating, StatusUpdatedAtTimestamp: longAgo},
+			"removing":        {Name: "removing", Status: EnvStatusRemoving, StatusUpdatedAtTimestamp: longAgo},
+			"recent_creating": {Name: "recent_creating", Status: EnvStatusCreating, StatusUpdatedAtTimestamp: recently},
+			// Removal started recently on an old env
+			"recent_removing": {
+				Name:                     "recent_removing",
+				Status:                   EnvStatusRemoving,
+				StatusUpdatedAtTimestamp: recently,
+				CreatedAtTimestamp:       longAgo,
+			},
+		},
+	}
+
+	config.Clusters["stale"] = &Cluster{
+		Name:                     "stale",
+		Status:                   ClusterStatusRemoving,
+		StatusUpdatedAtTimestamp: longAgo,
+		Envs: map[string]*Env{
+			"stale_env": {Name: "stale_env", Status: EnvStatusRemoving, StatusUpdatedAtTimestamp: longAgo},
+		},
+	}
+
+	config.Clusters["with_live_env"] = &Cluster{
+		Name:                     "with_live_env",
+		Status:                   ClusterStatusCreating,
+		StatusUpdatedAtTimestamp: longAgo,
+		Envs: map[string]*Env{
+			"live_env": {Name: "live_env", Status: EnvStatusCreated, StatusUpdatedAtTimestamp: longAgo},
+		},
+	}
+
+	config.Clusters["recent"] = &Cluster{
+		Name:                     "recent",
+		Status:                   ClusterStatusCreating,
+		StatusUpdatedAtTimestamp: recently,
+		Envs:                     map[string]*Env{},
+	}
+
+	staleResources := config.FindStaleResources(maxAge, now)
+
+	expectedStaleEnvNames := []string{"creating", "removing", "stale_env"}
+
+	if len(staleResources.Envs) != len(expectedStaleEnvNames) {
+		t.Fatalf(
+			"expected %d stale envs, got %d",
+			len(expectedStaleEnvNames),
+			len(staleResources.Envs),
+		)
+	}
+
+	for staleEnvIndex, staleEnv := range staleResources.Envs {
+		if staleEnv.Env.Name != expectedStaleEnvNames[staleEnvIndex] {
+			t.Fatalf(
+				"expected stale env to equal '%s', got '%s'",
+				expectedStaleEnvNames[staleEnvIndex],
+				staleEnv.Env.Name,
+			)
+		}
+	}
+
+	if len(staleResources.Clusters) != 1 ||
+		staleResources.Clusters[0].Name != "stale" {
+
+		t.Fatalf(
+			"expected only the cluster 'stale' to be stale, got '%+v'",
+			staleResources.Clusters,
+		)
+	}
+
+	if staleResources.IsEmpty() {
+		t.Fatalf("expected stale resources to not be empty")
+	}
+
+	if !NewConfig().FindStaleResources(maxAge, now).IsEmpty() {
+		t.Fatalf("expected no stale resources in empty config")
+	}
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigClone(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(
+		"cluster_name",
+		"default_instance_type",
+		true,
+	)
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	config.Clusters[cluster.Name] = cluster
+	cluster.Envs[env.Name] = env
+
+	clonedConfig, err := config.Clone()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(config, clonedConfig) {
+		t.Fatalf(
+			"expected cloned config to equal '%+v', got '%+v'",
+			config,
+			clonedConfig,
+		)
+	}
+
+	clonedConfig.Clusters[cluster.Name].Envs[env.Name].Name = "cloned_env_name"
+
+	if env.Name != "env_name" {
+		t.Fatalf("expected cloned config to not share envs")
+	}
+}
+
+func TestConfigSensitiveFields(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(
+		"cluster_name",
+		"default_instance_type",
+		true,
+	)
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	config.Clusters[cluster.Name] = cluster
+	cluster.Envs[env.Name] = env
+
+	sensitiveFields := config.SensitiveFields()
+
+	if len(sensitiveFields) != len(env.SensitiveFields()) {
+		t.Fatalf(
+			"expected %d sensitive fields, got %d",
+			len(env.SensitiveFields()),
+			len(sensitiveFields),
+		)
+	}
+
+	for _, field := range sensitiveFields {
+		*field = "redacted"
+	}
+
+	if env.SSHKeyPairPEMContent != "redacted" ||
+		env.PendingSSHKeyPairPEMContent != "redacted" {
+
+		t.Fatalf("expected sensitive fields to point to env fields")
+	}
+}
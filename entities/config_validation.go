@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ConfigStuckStatusThreshold is the duration after which an
+// env or a cluster still in creation is considered as stuck.
+const ConfigStuckStatusThreshold = 1 * time.Hour
+
+type ConfigIssueType string
+
+const (
+	ConfigIssueTypeClusterKeyMismatch            ConfigIssueType = "cluster_key_mismatch"
+	ConfigIssueTypeEnvKeyMismatch                ConfigIssueType = "env_key_mismatch"
+	ConfigIssueTypeDuplicatedLocalSSHCfgHostname ConfigIssueType = "duplicated_local_ssh_config_hostname"
+	ConfigIssueTypeDuplicatedDomain              ConfigIssueType = "duplicated_domain"
+	ConfigIssueTypeClusterStuckInCreation        ConfigIssueType = "cluster_stuck_in_creation"
+	ConfigIssueTypeEnvStuckInCreation            ConfigIssueType = "env_stuck_in_creation"
+	ConfigIssueTypeClusterNamespaceNotExists     ConfigIssueType = "cluster_namespace_not_exists"
+)
+
+// ConfigIssue describes an inconsistency found in a config
+// and how it could be fixed by the user.
+type ConfigIssue struct {
+	Type        ConfigIssueType
+	ClusterName string
+	EnvName     string
+	Description string
+	Fix         string
+}
+
+// Validate returns all the inconsistencies found in the config.
+// Contrary to CheckConsistency, it doesn't stop at the first issue.
+func (c *Config) Validate() []ConfigIssue {
+	return c.validate(time.Now())
+}
+
+func (c *Config) validate(now time.Time) []ConfigIssue {
+	issues := []ConfigIssue{}
+
+	envsByLocalSSHCfgHostname := map[string]string{}
+	envsByDomain := map[string]string{}
+
+	for _, clusterKey := range c.sortedClusterKeys() {
+		cluster := c.Clusters[clusterKey]
+
+		if cluster == nil {
+			continue
+		}
+
+		if cluster.Name != clusterKey {
+			issues = append(issues, ConfigIssue{
+				Type:        ConfigIssueTypeClusterKeyMismatch,
+				ClusterName: cluster.Name,
+				Description: fmt.Sprintf(
+					"cluster \"%s\" is stored under the key \"%s\"",
+					cluster.Name,
+					clusterKey,
+				),
+				Fix: "export the config, rename the key to match the cluster name and import it back",
+			})
+		}
+
+		if !c.NamespaceExists(cluster.Namespace) {
+			issues = append(issues, ConfigIssue{
+				Type:        ConfigIssueTypeClusterNamespaceNotExists,
+				ClusterName: cluster.Name,
+				Description: fmt.Sprintf(
+					"cluster \"%s\" belongs to the unknown namespace \"%s\"",
+					cluster.Name,
+					cluster.Namespace,
+				),
+				Fix: "move the cluster to an existing namespace",
+			})
+		}
+
+		if cluster.Status == ClusterStatusCreating &&
+			isOlderThan(cluster.StatusUpdatedAtTimestamp, ConfigStuckStatusThreshold, now) {
+
+			issues = append(issues, ConfigIssue{
+				Type:        ConfigIssueTypeClusterStuckInCreation,
+				ClusterName: cluster.Name,
+				Description: fmt.Sprintf(
+					"cluster \"%s\" has been in creation for more than %s",
+					cluster.Name,
+					ConfigStuckStatusThreshold,
+				),
+				Fix: "remove the cluster or run the command that created it again",
+			})
+		}
+
+		for _, envKey := range sortedEnvKeys(cluster) {
+			env := cluster.Envs[envKey]
+
+			if env == nil {
+				continue
+			}
+
+			if env.Name != envKey {
+				issues = append(issues, ConfigIssue{
+					Type:        ConfigIssueTypeEnvKeyMismatch,
+					ClusterName: cluster.Name,
+					EnvName:     env.Name,
+					Description: fmt.Sprintf(
+						"sandbox \"%s\" is stored under the key \"%s\"",
+						env.Name,
+						envKey,
+					),
+					Fix: "export the config, rename the key to match the sandbox name and import it back",
+				})
+			}
+
+			if otherEnvName, ok := envsByLocalSSHCfgHostname[env.LocalSSHConfigHostname]; ok {
+				issues = append(issues, ConfigIssue{
+					Type:        ConfigIssueTypeDuplicatedLocalSSHCfgHostname,
+					ClusterName: cluster.Name,
+					EnvName:     env.Name,
+					Description: fmt.Sprintf(
+						"sandboxes \"%s\" and \"%s\" share the SSH hostname \"%s\"",
+						otherEnvName,
+						env.Name,
+						env.LocalSSHConfigHostname,
+					),
+					Fix: fmt.Sprintf("remove and recreate the sandbox \"%s\"", env.Name),
+				})
+			} else {
+				envsByLocalSSHCfgHostname[env.LocalSSHConfigHostname] = env.Name
+			}
+
+			for _, domain := range env.sortedServedDomains() {
+				if otherEnvName, ok := envsByDomain[domain]; ok {
+					issues = append(issues, ConfigIssue{
+						Type:        ConfigIssueTypeDuplicatedDomain,
+						ClusterName: cluster.Name,
+						EnvName:     env.Name,
+						Description: fmt.Sprintf(
+							"domain \"%s\" is bound to sandboxes \"%s\" and \"%s\"",
+							domain,
+							otherEnvName,
+							env.Name,
+						),
+						Fix: fmt.Sprintf("unserve the domain \"%s\" in one of the sandboxes", domain),
+					})
+
+					continue
+				}
+
+				envsByDomain[domain] = env.Name
+			}
+
+			if env.Status == EnvStatusCreating &&
+				isOlderThan(env.StatusUpdatedAtTimestamp, ConfigStuckStatusThreshold, now) {
+
+				issues = append(issues, ConfigIssue{
+					Type:        ConfigIssueTypeEnvStuckInCreation,
+					ClusterName: cluster.Name,
+					EnvName:     env.Name,
+					Description: fmt.Sprintf(
+						"sandbox \"%s\" has been in creation for more than %s",
+						env.Name,
+						ConfigStuckStatusThreshold,
+					),
+					Fix: fmt.Sprintf("run \"init\" again or remove the sandbox \"%s\"", env.Name),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+func (c *Config) sortedClusterKeys() []string {
+	clusterKeys := []string{}
+
+	for clusterKey := range c.Clusters {
+		clusterKeys = append(clusterKeys, clusterKey)
+	}
+
+	sort.Strings(clusterKeys)
+
+	return clusterKeys
+}
+
+func sortedEnvKeys(cluster *Cluster) []string {
+	envKeys := []string{}
+
+	for envKey := range cluster.Envs {
+		envKeys = append(envKeys, envKey)
+	}
+
+	sort.Strings(envKeys)
+
+	return envKeys
+}
+
+func (e *Env) sortedServedDomains() []string {
+	domains := []string{}
+
+	for _, bindings := range e.ServedPorts {
+		for _, binding := range bindings {
+			if binding.Type != EnvServedPortBindingTypeDomain {
+				continue
+			}
+
+			domains = append(domains, binding.Value)
+		}
+	}
+
+	sort.Strings(domains)
+
+	return domains
+}
+
+func isOlderThan(timestamp int64, maxAge time.Duration, now time.Time) bool {
+	return now.Sub(time.Unix(timestamp, 0)) > maxAge
+}
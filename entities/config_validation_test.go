@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	now := time.Unix(100000, 0)
+	recently := now.Add(-1 * time.Minute).Unix()
+	longAgo := now.Add(-2 * ConfigStuckStatusThreshold).Unix()
+
+	buildEnv := func(name, hostname string, status EnvStatus, statusUpdatedAt int64) *Env {
+		return &Env{
+			Name:                     name,
+			LocalSSHConfigHostname:   hostname,
+			ServedPorts:              EnvServedPorts{},
+			Status:                   status,
+			StatusUpdatedAtTimestamp: statusUpdatedAt,
+		}
+	}
+
+	testCases := []struct {
+		test               string
+		buildConfig        func() *Config
+		expectedIssueTypes []ConfigIssueType
+	}{
+		{
+			test: "with valid config",
+			buildConfig: func() *Config {
+				config := NewConfig()
+				config.Clusters["default"] = &Cluster{
+					Namespace: DefaultNamespaceName,
+					Name:      "default",
+					Status:    ClusterStatusCreated,
+					Envs: map[string]*Env{
+						"env1": buildEnv("env1", "eleven/env1", EnvStatusCreated, longAgo),
+						"env2": buildEnv("env2", "eleven/env2", EnvStatusCreating, recently),
+					},
+				}
+				return config
+			},
+			expectedIssueTypes: []ConfigIssueType{},
+		},
+
+		{
+			test: "with unknown namespace",
+			buildConfig: func() *Config {
+				config := NewConfig()
+				config.Clusters["default"] = &Cluster{
+					Namespace: "unknown",
+					Name:      "default",
+					Status:    ClusterStatusCreated,
+					Envs:      map[string]*Env{},
+				}
+				return config
+			},
+			expectedIssueTypes: []ConfigIssueType{
+				ConfigIssueTypeClusterNamespaceNotExists,
+			},
+		},
+
+		{
+			test: "with key mismatches",
+			buildConfig: func() *Config {
+				config := NewConfig()
+				config.Clusters["default"] = &Cluster{
+					Namespace: DefaultNamespaceName,
+					Name:      "other",
+					Status:    ClusterStatusCreated,
+					Envs: map[string]*Env{
+						"env1": buildEnv("env2", "eleven/env2", EnvStatusCreated, longAgo),
+					},
+				}
+				return config
+			},
+			expectedIssueTypes: []ConfigIssueType{
+				ConfigIssueTypeClusterKeyMismatch,
+				ConfigIssueTypeEnvKeyMismatch,
+			},
+		},
+
+		{
+			test: "with duplicated hostnames and domains",
+			buildConfig: func() *Config {
+				env1 := buildEnv("env1", "eleven/env", EnvStatusCreated, longAgo)
+				env1.AddServedPortBinding("8080", "api.eleven.sh", false)
+
+				env2 := buildEnv("env2", "eleven/env", EnvStatusCreated, longAgo)
+				env2.AddServedPortBinding("3000", "api.eleven.sh", false)
+
+				config := NewConfig()
+				config.Clusters["default"] = &Cluster{
+					Namespace: DefaultNamespaceName,
+					Name:      "default",
+					Status:    ClusterStatusCreated,
+					Envs: map[string]*Env{
+						"env1": env1,
+						"env2": env2,
+					},
+				}
+				return config
+			},
+			expectedIssueTypes: []ConfigIssueType{
+				ConfigIssueTypeDuplicatedLocalSSHCfgHostname,
+				ConfigIssueTypeDuplicatedDomain,
+			},
+		},
+
+		{
+			test: "with stuck cluster and env",
+			buildConfig: func() *Config {
+				config := NewConfig()
+				config.Clusters["default"] = &Cluster{
+					Namespace:                DefaultNamespaceName,
+					Name:                     "default",
+					Status:                   ClusterStatusCreating,
+					StatusUpdatedAtTimestamp: longAgo,
+					Envs: map[string]*Env{
+						"env1": buildEnv("env1", "eleven/env1", EnvStatusCreating, longAgo),
+					},
+				}
+				return config
+			},
+			expectedIssueTypes: []ConfigIssueType{
+				ConfigIssueTypeClusterStuckInCreation,
+				ConfigIssueTypeEnvStuckInCreation,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			issues := tc.buildConfig().validate(now)
+
+			issueTypes := []ConfigIssueType{}
+			for _, issue := range issues {
+				issueTypes = append(issueTypes, issue.Type)
+
+				if len(issue.Fix) == 0 {
+					t.Fatalf("expected issue '%s' to have a fix", issue.Type)
+				}
+			}
+
+			if !reflect.DeepEqual(tc.expectedIssueTypes, issueTypes) {
+				t.Fatalf(
+					"expected issues to equal '%+v', got '%+v'",
+					tc.expectedIssueTypes,
+					issueTypes,
+				)
+			}
+		})
+	}
+}
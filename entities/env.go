@@ -29,23 +29,34 @@ const (
 	EnvStatusCreating EnvStatus = "creating"
 	EnvStatusCreated  EnvStatus = "created"
 	EnvStatusRemoving EnvStatus = "removing"
+	EnvStatusStopped  EnvStatus = "stopped"
 )
 
 type Env struct {
-	ID                       string          `json:"id"`
-	Name                     string          `json:"name"`
-	LocalSSHConfigHostname   string          `json:"local_ssh_config_hostname"`
-	InfrastructureJSON       string          `json:"infrastructure_json"`
-	InstanceType             string          `json:"instance_type"`
-	InstancePublicIPAddress  string          `json:"instance_public_ip_address"`
-	SSHHostKeys              []EnvSSHHostKey `json:"ssh_host_keys"`
-	SSHKeyPairPEMContent     string          `json:"ssh_key_pair_pem_content"`
-	Repositories             []EnvRepository `json:"repositories"`
-	Runtimes                 EnvRuntimes     `json:"runtimes"`
-	ServedPorts              EnvServedPorts  `json:"served_ports"`
-	Status                   EnvStatus       `json:"status"`
-	AdditionalPropertiesJSON string          `json:"additional_properties_json"`
-	CreatedAtTimestamp       int64           `json:"created_at_timestamp"`
+	ID                          string               `json:"id"`
+	Name                        string               `json:"name"`
+	Owner                       string               `json:"owner"`
+	LocalSSHConfigHostname      string               `json:"local_ssh_config_hostname"`
+	InfrastructureJSON          string               `json:"infrastructure_json"`
+	InstanceType                string               `json:"instance_type"`
+	InstancePublicIPAddress     string               `json:"instance_public_ip_address"`
+	SSHHostKeys                 []EnvSSHHostKey      `json:"ssh_host_keys"`
+	SSHKeyPairPEMContent        string               `json:"ssh_key_pair_pem_content"`
+	PendingSSHKeyPairPEMContent string               `json:"pending_ssh_key_pair_pem_content"`
+	GitHubSSHKeyScope           EnvGitHubSSHKeyScope `json:"github_ssh_key_scope"`
+	Repositories                []EnvRepository      `json:"repositories"`
+	Runtimes                    EnvRuntimes          `json:"runtimes"`
+	ServedPorts                 EnvServedPorts       `json:"served_ports"`
+	Secrets                     EnvSecrets           `json:"secrets"`
+	Collaborators               EnvCollaborators     `json:"collaborators"`
+	Labels                      Labels               `json:"labels"`
+	Status                      EnvStatus            `json:"status"`
+	StatusUpdatedAtTimestamp    int64                `json:"status_updated_at_timestamp"`
+	AdditionalPropertiesJSON    string               `json:"additional_properties_json"`
+	ExpiresAtTimestamp          int64                `json:"expires_at_timestamp"`
+	TTLSeconds                  int64                `json:"ttl_seconds"`
+	IdlePolicy                  *IdlePolicy          `json:"idle_policy"`
+	CreatedAtTimestamp          int64                `json:"created_at_timestamp"`
 }
 
 func NewEnv(
@@ -56,6 +67,8 @@ func NewEnv(
 	runtimes EnvRuntimes,
 ) *Env {
 
+	now := time.Now().Unix()
+
 	return &Env{
 		ID:   uuid.NewString(),
 		Name: envName,
@@ -63,16 +76,28 @@ func NewEnv(
 			envName,
 			localSSHCfgDupHostCt,
 		),
-		InstanceType:       instanceType,
-		SSHHostKeys:        []EnvSSHHostKey{},
-		Repositories:       repositories,
-		Runtimes:           runtimes,
-		ServedPorts:        EnvServedPorts{},
-		Status:             EnvStatusCreating,
-		CreatedAtTimestamp: time.Now().Unix(),
+		InstanceType:             instanceType,
+		SSHHostKeys:              []EnvSSHHostKey{},
+		GitHubSSHKeyScope:        EnvGitHubSSHKeyScopeUser,
+		Repositories:             repositories,
+		Runtimes:                 runtimes,
+		ServedPorts:              EnvServedPorts{},
+		Secrets:                  EnvSecrets{},
+		Collaborators:            EnvCollaborators{},
+		Labels:                   Labels{},
+		Status:                   EnvStatusCreating,
+		StatusUpdatedAtTimestamp: now,
+		CreatedAtTimestamp:       now,
 	}
 }
 
+// SetStatus updates the status of the env
+// and records the time of the change
+func (e *Env) SetStatus(status EnvStatus) {
+	e.Status = status
+	e.StatusUpdatedAtTimestamp = time.Now().Unix()
+}
+
 func (e *Env) GetNameSlug() string {
 	return BuildSlugForEnv(e.Name)
 }
@@ -81,6 +106,49 @@ func (e *Env) GetSSHKeyPairName() string {
 	return BuildSlugForEnv(e.LocalSSHConfigHostname)
 }
 
+func (e *Env) GetSSHPublicKeyContent() (string, error) {
+	if len(e.SSHKeyPairPEMContent) == 0 {
+		return "", ErrEnvSSHKeyPairNotGenerated{
+			EnvName: e.Name,
+		}
+	}
+
+	return BuildSSHPublicKeyContent(e.SSHKeyPairPEMContent)
+}
+
+// Clone returns a deep copy of the env
+func (e *Env) Clone() (*Env, error) {
+	envJSON, err := json.Marshal(e)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var clonedEnv *Env
+	err = json.Unmarshal(envJSON, &clonedEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clonedEnv, nil
+}
+
+// SensitiveFields returns pointers to all the
+// env's fields that must be encrypted at rest.
+func (e *Env) SensitiveFields() []*string {
+	sensitiveFields := []*string{
+		&e.SSHKeyPairPEMContent,
+		&e.PendingSSHKeyPairPEMContent,
+	}
+
+	for _, secret := range e.Secrets {
+		sensitiveFields = append(sensitiveFields, &secret.Value)
+	}
+
+	return sensitiveFields
+}
+
 func (e *Env) SetInfrastructureJSON(infrastructure interface{}) error {
 	infrastructureJSON, err := json.Marshal(infrastructure)
 
@@ -148,6 +216,18 @@ func ParseSSHHostKeysForEnv(hostKeysContent string) ([]EnvSSHHostKey, error) {
 	return parsedHostKeys, nil
 }
 
+func BuildSSHPublicKeyContent(privateKeyPEMContent string) (string, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKeyPEMContent))
+
+	if err != nil {
+		return "", err
+	}
+
+	publicKeyContent := ssh.MarshalAuthorizedKey(signer.PublicKey())
+
+	return strings.TrimSpace(string(publicKeyContent)), nil
+}
+
 func CheckEnvNameValidity(envName string) error {
 	validEnvName := govalidator.Matches(
 		envName,
@@ -0,0 +1,78 @@
+package entities
+
+import "github.com/google/uuid"
+
+// NewEnvFromSource returns a new env that shares the instance
+// type, runtimes and repositories of the source env.
+// SSH keys, deploy keys, secrets, served ports and
+// collaborators are not copied.
+func NewEnvFromSource(
+	envName string,
+	localSSHCfgDupHostCt int,
+	sourceEnv *Env,
+) *Env {
+
+	repositories := []EnvRepository{}
+
+	for _, repository := range sourceEnv.Repositories {
+		// Deploy keys are bound to the
+		// SSH key pair of the source env
+		repository.DeployKeyID = 0
+		repositories = append(repositories, repository)
+	}
+
+	runtimes := EnvRuntimes{}
+
+	for runtimeName, runtimeVersion := range sourceEnv.Runtimes {
+		runtimes[runtimeName] = runtimeVersion
+	}
+
+	env := NewEnv(
+		envName,
+		localSSHCfgDupHostCt,
+		sourceEnv.InstanceType,
+		repositories,
+		runtimes,
+	)
+
+	env.GitHubSSHKeyScope = sourceEnv.GitHubSSHKeyScope
+
+	return env
+}
+
+// NewEnvForMove returns a copy of the source env that could be
+// created in another cluster. Everything that lives on the env's
+// disk (SSH keys, host keys, repositories...) is kept but
+// the infrastructure is reset.
+func NewEnvForMove(sourceEnv *Env) (*Env, error) {
+	env, err := sourceEnv.Clone()
+
+	if err != nil {
+		return nil, err
+	}
+
+	env.ID = uuid.NewString()
+	env.InfrastructureJSON = ""
+	env.InstancePublicIPAddress = ""
+	env.SetStatus(EnvStatusCreating)
+
+	return env, nil
+}
+
+// GetPortBoundServedPorts returns the served ports of
+// the env without the ones bound to domains
+func (e *Env) GetPortBoundServedPorts() EnvServedPorts {
+	servedPorts := EnvServedPorts{}
+
+	for servedPort, bindings := range e.ServedPorts {
+		for _, binding := range bindings {
+			if binding.Type != EnvServedPortBindingTypePort {
+				continue
+			}
+
+			servedPorts[servedPort] = append(servedPorts[servedPort], binding)
+		}
+	}
+
+	return servedPorts
+}
@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewEnvFromSource(t *testing.T) {
+	sourceEnv := NewEnv(
+		"source",
+		0,
+		"t2.medium",
+		[]EnvRepository{
+			{
+				Name:        "eleven",
+				Owner:       "eleven-sh",
+				DeployKeyID: 42,
+			},
+		},
+		EnvRuntimes{
+			"go": "1.19",
+		},
+	)
+
+	sourceEnv.GitHubSSHKeyScope = EnvGitHubSSHKeyScopeReadOnlyDeployKeys
+	sourceEnv.SSHKeyPairPEMContent = "pem_content"
+	sourceEnv.AddServedPortBinding("8080", "8080", false)
+
+	env := NewEnvFromSource("clone", 1, sourceEnv)
+
+	if env.ID == sourceEnv.ID {
+		t.Fatalf("expected cloned env to have a new ID")
+	}
+
+	if env.Name != "clone" || env.LocalSSHConfigHostname != "eleven/clone-1" {
+		t.Fatalf(
+			"expected cloned env to be named 'clone' with hostname 'eleven/clone-1', got '%s' and '%s'",
+			env.Name,
+			env.LocalSSHConfigHostname,
+		)
+	}
+
+	if env.InstanceType != sourceEnv.InstanceType {
+		t.Fatalf(
+			"expected instance type to equal '%s', got '%s'",
+			sourceEnv.InstanceType,
+			env.InstanceType,
+		)
+	}
+
+	if !reflect.DeepEqual(env.Runtimes, sourceEnv.Runtimes) {
+		t.Fatalf(
+			"expected runtimes to equal '%+v', got '%+v'",
+			sourceEnv.Runtimes,
+			env.Runtimes,
+		)
+	}
+
+	if env.GitHubSSHKeyScope != sourceEnv.GitHubSSHKeyScope {
+		t.Fatalf(
+			"expected GitHub SSH key scope to equal '%s', got '%s'",
+			sourceEnv.GitHubSSHKeyScope,
+			env.GitHubSSHKeyScope,
+		)
+	}
+
+	if len(env.Repositories) != 1 ||
+		env.Repositories[0].Name != "eleven" ||
+		env.Repositories[0].DeployKeyID != 0 {
+
+		t.Fatalf("expected repositories to be copied without deploy keys, got '%+v'", env.Repositories)
+	}
+
+	if sourceEnv.Repositories[0].DeployKeyID != 42 {
+		t.Fatalf("expected source env repositories to be left untouched")
+	}
+
+	if len(env.SSHKeyPairPEMContent) > 0 || len(env.ServedPorts) > 0 {
+		t.Fatalf("expected SSH key pair and served ports to not be copied")
+	}
+
+	if env.Status != EnvStatusCreating {
+		t.Fatalf(
+			"expected status to equal '%s', got '%s'",
+			EnvStatusCreating,
+			env.Status,
+		)
+	}
+}
+
+func TestNewEnvForMove(t *testing.T) {
+	sourceEnv := NewEnv(
+		"source",
+		0,
+		"t2.medium",
+		[]EnvRepository{
+			{
+				Name:        "eleven",
+				Owner:       "eleven-sh",
+				DeployKeyID: 42,
+			},
+		},
+		EnvRuntimes{
+			"go": "1.19",
+		},
+	)
+
+	sourceEnv.Status = EnvStatusCreated
+	sourceEnv.InfrastructureJSON = "{}"
+	sourceEnv.InstancePublicIPAddress = "127.0.0.1"
+	sourceEnv.SSHKeyPairPEMContent = "pem_content"
+	sourceEnv.SSHHostKeys = []EnvSSHHostKey{
+		{
+			Algorithm:   "ssh-ed25519",
+			Fingerprint: "fingerprint",
+		},
+	}
+	sourceEnv.AddServedPortBinding("8080", "example.com", true)
+
+	env, err := NewEnvForMove(sourceEnv)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.ID == sourceEnv.ID {
+		t.Fatalf("expected moved env to have a new ID")
+	}
+
+	if len(env.InfrastructureJSON) > 0 ||
+		len(env.InstancePublicIPAddress) > 0 ||
+		env.Status != EnvStatusCreating {
+
+		t.Fatalf("expected moved env infrastructure to be reset, got '%+v'", env)
+	}
+
+	if env.Name != sourceEnv.Name ||
+		env.SSHKeyPairPEMContent != sourceEnv.SSHKeyPairPEMContent ||
+		!reflect.DeepEqual(env.SSHHostKeys, sourceEnv.SSHHostKeys) ||
+		!reflect.DeepEqual(env.Repositories, sourceEnv.Repositories) ||
+		!reflect.DeepEqual(env.Runtimes, sourceEnv.Runtimes) ||
+		!reflect.DeepEqual(env.ServedPorts, sourceEnv.ServedPorts) {
+
+		t.Fatalf(
+			"expected moved env to keep the source env properties, got '%+v'",
+			env,
+		)
+	}
+
+	env.SSHHostKeys[0].Fingerprint = "updated"
+
+	if sourceEnv.SSHHostKeys[0].Fingerprint != "fingerprint" {
+		t.Fatalf("expected source env to be left untouched")
+	}
+}
+
+func TestGetPortBoundServedPorts(t *testing.T) {
+	env := &Env{
+		ServedPorts: EnvServedPorts{},
+	}
+
+	env.AddServedPortBinding("8080", "8080", false)
+	env.AddServedPortBinding("8080", "example.com", true)
+	env.AddServedPortBinding("3000", "api.example.com", true)
+	env.AddServedPortBinding("4000", "4001", false)
+
+	expectedServedPorts := EnvServedPorts{
+		"8080": []EnvServedPortBinding{
+			{
+				Value: "8080",
+				Type:  EnvServedPortBindingTypePort,
+			},
+		},
+
+		"4000": []EnvServedPortBinding{
+			{
+				Value: "4001",
+				Type:  EnvServedPortBindingTypePort,
+			},
+		},
+	}
+
+	servedPorts := env.GetPortBoundServedPorts()
+
+	if !reflect.DeepEqual(expectedServedPorts, servedPorts) {
+		t.Fatalf(
+			"expected served ports to equal '%+v', got '%+v'",
+			expectedServedPorts,
+			servedPorts,
+		)
+	}
+}
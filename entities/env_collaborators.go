@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const GitHubUsernameRegExp = `^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`
+const GitHubUsernameMaxLength = 39
+
+type EnvCollaboratorRole string
+
+const (
+	// EnvCollaboratorRoleViewer grants a read-only access
+	// by logging viewers in as the unprivileged
+	// EnvViewerUsername user (see EnvAuthorizedKeyViewerOptions)
+	EnvCollaboratorRoleViewer EnvCollaboratorRole = "viewer"
+	// EnvCollaboratorRoleEditor grants a full access.
+	// It's the role of the env's own SSH key pair.
+	EnvCollaboratorRoleEditor EnvCollaboratorRole = "editor"
+)
+
+var EnvCollaboratorRoles = []EnvCollaboratorRole{
+	EnvCollaboratorRoleViewer,
+	EnvCollaboratorRoleEditor,
+}
+
+// EnvCollaborator is a GitHub user that was granted an access
+// to an env using the public keys registered on its GitHub account.
+type EnvCollaborator struct {
+	Username           string              `json:"username"`
+	Role               EnvCollaboratorRole `json:"role"`
+	PublicKeys         []string            `json:"public_keys"`
+	CreatedAtTimestamp int64               `json:"created_at_timestamp"`
+}
+
+// EnvCollaborators are indexed by lowercased
+// username given that GitHub usernames
+// are case-insensitive.
+type EnvCollaborators map[string]*EnvCollaborator
+
+func NewEnvCollaborator(
+	username string,
+	role EnvCollaboratorRole,
+	publicKeys []string,
+) *EnvCollaborator {
+
+	return &EnvCollaborator{
+		Username:           username,
+		Role:               role,
+		PublicKeys:         publicKeys,
+		CreatedAtTimestamp: time.Now().Unix(),
+	}
+}
+
+func ParseEnvCollaboratorRole(role string) (EnvCollaboratorRole, error) {
+	for _, validRole := range EnvCollaboratorRoles {
+		if string(validRole) == role {
+			return validRole, nil
+		}
+	}
+
+	return "", ErrInvalidEnvCollaboratorRole{
+		Role:       role,
+		ValidRoles: EnvCollaboratorRoles,
+	}
+}
+
+func CheckGitHubUsernameValidity(username string) error {
+	validUsername := govalidator.Matches(
+		username,
+		GitHubUsernameRegExp,
+	)
+
+	if !validUsername || len(username) > GitHubUsernameMaxLength {
+		return ErrInvalidGitHubUsername{
+			Username: username,
+		}
+	}
+
+	return nil
+}
+
+func (e *Env) CollaboratorExists(username string) bool {
+	_, collaboratorExists := e.Collaborators[strings.ToLower(username)]
+	return collaboratorExists
+}
+
+func (e *Env) GetCollaborator(username string) (*EnvCollaborator, error) {
+	if !e.CollaboratorExists(username) {
+		return nil, ErrEnvCollaboratorNotExists{
+			EnvName:  e.Name,
+			Username: username,
+		}
+	}
+
+	return e.Collaborators[strings.ToLower(username)], nil
+}
+
+func (e *Env) SetCollaborator(collaborator *EnvCollaborator) {
+	if e.Collaborators == nil {
+		e.Collaborators = EnvCollaborators{}
+	}
+
+	e.Collaborators[strings.ToLower(collaborator.Username)] = collaborator
+}
+
+func (e *Env) RemoveCollaborator(username string) error {
+	if !e.CollaboratorExists(username) {
+		return ErrEnvCollaboratorNotExists{
+			EnvName:  e.Name,
+			Username: username,
+		}
+	}
+
+	delete(e.Collaborators, strings.ToLower(username))
+
+	return nil
+}
+
+func (e *Env) GetSortedCollaborators() []*EnvCollaborator {
+	collaborators := []*EnvCollaborator{}
+
+	for _, collaborator := range e.Collaborators {
+		collaborators = append(collaborators, collaborator)
+	}
+
+	sort.Slice(collaborators, func(i, j int) bool {
+		return strings.ToLower(collaborators[i].Username) <
+			strings.ToLower(collaborators[j].Username)
+	})
+
+	return collaborators
+}
@@ -0,0 +1,51 @@
+package entities
+
+type ErrInvalidEnvCollaboratorRole struct {
+	Role       string
+	ValidRoles []EnvCollaboratorRole
+}
+
+func (ErrInvalidEnvCollaboratorRole) Error() string {
+	return "ErrInvalidEnvCollaboratorRole"
+}
+
+type ErrInvalidGitHubUsername struct {
+	Username string
+}
+
+func (ErrInvalidGitHubUsername) Error() string {
+	return "ErrInvalidGitHubUsername"
+}
+
+type ErrEnvCollaboratorNotExists struct {
+	EnvName  string
+	Username string
+}
+
+func (ErrEnvCollaboratorNotExists) Error() string {
+	return "ErrEnvCollaboratorNotExists"
+}
+
+type ErrCollaboratorWithoutPublicKeys struct {
+	Username string
+}
+
+func (ErrCollaboratorWithoutPublicKeys) Error() string {
+	return "ErrCollaboratorWithoutPublicKeys"
+}
+
+type ErrAccessRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrAccessRemovingEnv) Error() string {
+	return "ErrAccessRemovingEnv"
+}
+
+type ErrAccessCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrAccessCreatingEnv) Error() string {
+	return "ErrAccessCreatingEnv"
+}
@@ -0,0 +1,175 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvCollaboratorRole(t *testing.T) {
+	testCases := []struct {
+		test          string
+		role          string
+		expectedRole  EnvCollaboratorRole
+		expectedError bool
+	}{
+		{
+			test:         "with viewer role",
+			role:         "viewer",
+			expectedRole: EnvCollaboratorRoleViewer,
+		},
+
+		{
+			test:         "with editor role",
+			role:         "editor",
+			expectedRole: EnvCollaboratorRoleEditor,
+		},
+
+		{
+			test:          "with invalid role",
+			role:          "admin",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			role, err := ParseEnvCollaboratorRole(tc.role)
+
+			if tc.expectedError && !errors.As(err, &ErrInvalidEnvCollaboratorRole{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrInvalidEnvCollaboratorRole{},
+					err,
+				)
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if role != tc.expectedRole {
+				t.Fatalf(
+					"expected role to equal '%s', got '%s'",
+					tc.expectedRole,
+					role,
+				)
+			}
+		})
+	}
+}
+
+func TestCheckGitHubUsernameValidity(t *testing.T) {
+	testCases := []struct {
+		test          string
+		username      string
+		expectedError bool
+	}{
+		{
+			test:     "with valid username",
+			username: "Jeremy-L",
+		},
+
+		{
+			test:          "with leading hyphen",
+			username:      "-jeremy",
+			expectedError: true,
+		},
+
+		{
+			test:          "with consecutive hyphens",
+			username:      "jeremy--l",
+			expectedError: true,
+		},
+
+		{
+			test:          "with too long username",
+			username:      strings.Repeat("a", GitHubUsernameMaxLength+1),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckGitHubUsernameValidity(tc.username)
+
+			if tc.expectedError && !errors.As(err, &ErrInvalidGitHubUsername{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrInvalidGitHubUsername{},
+					err,
+				)
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+		})
+	}
+}
+
+func TestEnvCollaborators(t *testing.T) {
+	env := &Env{Name: "env"}
+
+	env.SetCollaborator(NewEnvCollaborator(
+		"Zoe",
+		EnvCollaboratorRoleEditor,
+		[]string{"ssh-ed25519 zoe_key"},
+	))
+
+	env.SetCollaborator(NewEnvCollaborator(
+		"bob",
+		EnvCollaboratorRoleEditor,
+		[]string{"ssh-rsa bob_key"},
+	))
+
+	// Usernames are case-insensitive
+	env.SetCollaborator(NewEnvCollaborator(
+		"BOB",
+		EnvCollaboratorRoleViewer,
+		[]string{"ssh-rsa bob_key"},
+	))
+
+	collaborator, err := env.GetCollaborator("Bob")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if collaborator.Role != EnvCollaboratorRoleViewer {
+		t.Fatalf(
+			"expected role to equal '%s', got '%s'",
+			EnvCollaboratorRoleViewer,
+			collaborator.Role,
+		)
+	}
+
+	sortedCollaborators := env.GetSortedCollaborators()
+
+	if len(sortedCollaborators) != 2 ||
+		sortedCollaborators[0].Username != "BOB" ||
+		sortedCollaborators[1].Username != "Zoe" {
+
+		t.Fatalf("expected collaborators to be sorted by username, got '%+v'", sortedCollaborators)
+	}
+
+	err = env.RemoveCollaborator("zoe")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.CollaboratorExists("Zoe") {
+		t.Fatalf("expected collaborator to be removed")
+	}
+
+	err = env.RemoveCollaborator("zoe")
+
+	if !errors.As(err, &ErrEnvCollaboratorNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrEnvCollaboratorNotExists{},
+			err,
+		)
+	}
+}
@@ -59,3 +59,163 @@ type ErrEnvCloudInitError struct {
 func (ErrEnvCloudInitError) Error() string {
 	return "ErrEnvCloudInitError"
 }
+
+type ErrEnvSSHKeyPairNotGenerated struct {
+	EnvName string
+}
+
+func (ErrEnvSSHKeyPairNotGenerated) Error() string {
+	return "ErrEnvSSHKeyPairNotGenerated"
+}
+
+type ErrRotateKeysRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrRotateKeysRemovingEnv) Error() string {
+	return "ErrRotateKeysRemovingEnv"
+}
+
+type ErrRotateKeysCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrRotateKeysCreatingEnv) Error() string {
+	return "ErrRotateKeysCreatingEnv"
+}
+
+type ErrSSHKeyRotationNotConfirmed struct {
+	EnvName string
+}
+
+func (ErrSSHKeyRotationNotConfirmed) Error() string {
+	return "ErrSSHKeyRotationNotConfirmed"
+}
+
+type ErrResizeRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrResizeRemovingEnv) Error() string {
+	return "ErrResizeRemovingEnv"
+}
+
+type ErrResizeCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrResizeCreatingEnv) Error() string {
+	return "ErrResizeCreatingEnv"
+}
+
+type ErrEnvAlreadyExists struct {
+	ClusterName string
+	EnvName     string
+}
+
+func (ErrEnvAlreadyExists) Error() string {
+	return "ErrEnvAlreadyExists"
+}
+
+type ErrCloneRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrCloneRemovingEnv) Error() string {
+	return "ErrCloneRemovingEnv"
+}
+
+type ErrCloneCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrCloneCreatingEnv) Error() string {
+	return "ErrCloneCreatingEnv"
+}
+
+type ErrRenameRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrRenameRemovingEnv) Error() string {
+	return "ErrRenameRemovingEnv"
+}
+
+type ErrRenameCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrRenameCreatingEnv) Error() string {
+	return "ErrRenameCreatingEnv"
+}
+
+type ErrMoveRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrMoveRemovingEnv) Error() string {
+	return "ErrMoveRemovingEnv"
+}
+
+type ErrMoveCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrMoveCreatingEnv) Error() string {
+	return "ErrMoveCreatingEnv"
+}
+
+type ErrMoveSameCluster struct {
+	ClusterName string
+}
+
+func (ErrMoveSameCluster) Error() string {
+	return "ErrMoveSameCluster"
+}
+
+type ErrMoveUnhealthyEnv struct {
+	ClusterName string
+	EnvName     string
+}
+
+func (ErrMoveUnhealthyEnv) Error() string {
+	return "ErrMoveUnhealthyEnv"
+}
+
+type ErrMoveHealthCheckRequired struct {
+	EnvName string
+}
+
+func (ErrMoveHealthCheckRequired) Error() string {
+	return "ErrMoveHealthCheckRequired"
+}
+
+type ErrMoveUnsupported struct {
+	SourceClusterName string
+	TargetClusterName string
+}
+
+func (ErrMoveUnsupported) Error() string {
+	return "ErrMoveUnsupported"
+}
+
+type ErrEnvOwnerMismatch struct {
+	Owner string
+	Actor string
+}
+
+func (ErrEnvOwnerMismatch) Error() string {
+	return "ErrEnvOwnerMismatch"
+}
+
+// ErrEnvOperationOutOfScope is returned when an operation run
+// on an env, in parallel with others, modifies the config
+// outside of its env. Only the env changes could be merged.
+type ErrEnvOperationOutOfScope struct {
+	ClusterName string
+	EnvName     string
+}
+
+func (ErrEnvOperationOutOfScope) Error() string {
+	return "ErrEnvOperationOutOfScope"
+}
@@ -0,0 +1,139 @@
+package entities
+
+import "time"
+
+type EnvExpiryAction string
+
+const (
+	EnvExpiryActionStop   EnvExpiryAction = "stop"
+	EnvExpiryActionRemove EnvExpiryAction = "remove"
+)
+
+func ParseEnvExpiryAction(action string) (EnvExpiryAction, error) {
+	switch EnvExpiryAction(action) {
+	case EnvExpiryActionStop, EnvExpiryActionRemove:
+		return EnvExpiryAction(action), nil
+	}
+
+	return "", ErrInvalidEnvExpiryAction{
+		Action: action,
+		ValidActions: []EnvExpiryAction{
+			EnvExpiryActionStop,
+			EnvExpiryActionRemove,
+		},
+	}
+}
+
+func CheckEnvTTLValidity(ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidEnvTTL{
+			TTL: ttl,
+		}
+	}
+
+	return nil
+}
+
+func (e *Env) HasExpiry() bool {
+	return e.ExpiresAtTimestamp > 0
+}
+
+func (e *Env) GetExpiresAt() time.Time {
+	return time.Unix(e.ExpiresAtTimestamp, 0)
+}
+
+func (e *Env) SetTTL(ttl time.Duration, now time.Time) error {
+	err := CheckEnvTTLValidity(ttl)
+
+	if err != nil {
+		return err
+	}
+
+	e.TTLSeconds = int64(ttl.Seconds())
+	e.ExpiresAtTimestamp = now.Add(ttl).Unix()
+
+	return nil
+}
+
+// RenewExpiry must be called when an env is started.
+// Expired envs get their TTL back from now so that they are
+// not stopped again by the next sweep. The expiry of envs
+// whose TTL is unknown is removed.
+func (e *Env) RenewExpiry(now time.Time) {
+	if !e.HasExpiry() || e.GetExpiresAt().After(now) {
+		return
+	}
+
+	if e.TTLSeconds <= 0 {
+		e.ExpiresAtTimestamp = 0
+		return
+	}
+
+	e.ExpiresAtTimestamp = now.Add(
+		time.Duration(e.TTLSeconds) * time.Second,
+	).Unix()
+}
+
+// ExtendExpiry postpones the expiry by the passed duration.
+// Envs that have already expired (or that have no expiry)
+// are extended from now.
+func (e *Env) ExtendExpiry(duration time.Duration, now time.Time) error {
+	err := CheckEnvTTLValidity(duration)
+
+	if err != nil {
+		return err
+	}
+
+	if !e.HasExpiry() || e.GetExpiresAt().Before(now) {
+		return e.SetTTL(duration, now)
+	}
+
+	e.ExpiresAtTimestamp = e.GetExpiresAt().Add(duration).Unix()
+
+	return nil
+}
+
+// IsExpired returns true if the env has expired
+// for more than the passed grace period
+func (e *Env) IsExpired(gracePeriod time.Duration, now time.Time) bool {
+	if !e.HasExpiry() {
+		return false
+	}
+
+	return now.After(e.GetExpiresAt().Add(gracePeriod))
+}
+
+// FindExpiredEnvs returns the envs of the cluster that have expired
+// and on which the passed action could be applied, sorted by name.
+func (c *Config) FindExpiredEnvs(
+	clusterName string,
+	action EnvExpiryAction,
+	gracePeriod time.Duration,
+	now time.Time,
+) ([]*Env, error) {
+
+	cluster, err := c.GetCluster(clusterName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	expiredEnvs := []*Env{}
+
+	for _, env := range cluster.GetSortedEnvs() {
+		if !env.IsExpired(gracePeriod, now) {
+			continue
+		}
+
+		actionApplicable := env.Status == EnvStatusCreated ||
+			(action == EnvExpiryActionRemove && env.Status == EnvStatusStopped)
+
+		if !actionApplicable {
+			continue
+		}
+
+		expiredEnvs = append(expiredEnvs, env)
+	}
+
+	return expiredEnvs, nil
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+type ErrInvalidEnvExpiryAction struct {
+	Action       string
+	ValidActions []EnvExpiryAction
+}
+
+func (ErrInvalidEnvExpiryAction) Error() string {
+	return "ErrInvalidEnvExpiryAction"
+}
+
+type ErrInvalidEnvTTL struct {
+	TTL time.Duration
+}
+
+func (ErrInvalidEnvTTL) Error() string {
+	return "ErrInvalidEnvTTL"
+}
+
+type ErrExtendRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrExtendRemovingEnv) Error() string {
+	return "ErrExtendRemovingEnv"
+}
@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseEnvExpiryAction(t *testing.T) {
+	for _, action := range []string{"stop", "remove"} {
+		parsedAction, err := ParseEnvExpiryAction(action)
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		if string(parsedAction) != action {
+			t.Fatalf("expected action to equal '%s', got '%s'", action, parsedAction)
+		}
+	}
+
+	_, err := ParseEnvExpiryAction("pause")
+
+	if !errors.As(err, &ErrInvalidEnvExpiryAction{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidEnvExpiryAction{},
+			err,
+		)
+	}
+}
+
+func TestEnvExtendExpiry(t *testing.T) {
+	now := time.Unix(100000, 0)
+
+	testCases := []struct {
+		test                       string
+		expiresAtTimestamp         int64
+		duration                   time.Duration
+		expectedExpiresAtTimestamp int64
+		expectedError              error
+	}{
+		{
+			test:                       "with no expiry",
+			expiresAtTimestamp:         0,
+			duration:                   time.Hour,
+			expectedExpiresAtTimestamp: now.Add(time.Hour).Unix(),
+		},
+
+		{
+			test:                       "with future expiry",
+			expiresAtTimestamp:         now.Add(time.Hour).Unix(),
+			duration:                   time.Hour,
+			expectedExpiresAtTimestamp: now.Add(2 * time.Hour).Unix(),
+		},
+
+		{
+			test:                       "with past expiry",
+			expiresAtTimestamp:         now.Add(-time.Hour).Unix(),
+			duration:                   time.Hour,
+			expectedExpiresAtTimestamp: now.Add(time.Hour).Unix(),
+		},
+
+		{
+			test:                       "with invalid duration",
+			expiresAtTimestamp:         0,
+			duration:                   -time.Hour,
+			expectedExpiresAtTimestamp: 0,
+			expectedError:              ErrInvalidEnvTTL{TTL: -time.Hour},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{ExpiresAtTimestamp: tc.expiresAtTimestamp}
+
+			err := env.ExtendExpiry(tc.duration, now)
+
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+
+			if env.ExpiresAtTimestamp != tc.expectedExpiresAtTimestamp {
+				t.Fatalf(
+					"expected expiry to equal %d, got %d",
+					tc.expectedExpiresAtTimestamp,
+					env.ExpiresAtTimestamp,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvRenewExpiry(t *testing.T) {
+	now := time.Unix(100000, 0)
+
+	testCases := []struct {
+		test                       string
+		expiresAtTimestamp         int64
+		ttlSeconds                 int64
+		expectedExpiresAtTimestamp int64
+	}{
+		{
+			test:                       "with no expiry",
+			expiresAtTimestamp:         0,
+			ttlSeconds:                 0,
+			expectedExpiresAtTimestamp: 0,
+		},
+
+		{
+			test:                       "with future expiry",
+			expiresAtTimestamp:         now.Add(time.Hour).Unix(),
+			ttlSeconds:                 int64((2 * time.Hour).Seconds()),
+			expectedExpiresAtTimestamp: now.Add(time.Hour).Unix(),
+		},
+
+		{
+			test:                       "with past expiry",
+			expiresAtTimestamp:         now.Add(-time.Hour).Unix(),
+			ttlSeconds:                 int64((2 * time.Hour).Seconds()),
+			expectedExpiresAtTimestamp: now.Add(2 * time.Hour).Unix(),
+		},
+
+		{
+			test:                       "with past expiry and unknown TTL",
+			expiresAtTimestamp:         now.Add(-time.Hour).Unix(),
+			ttlSeconds:                 0,
+			expectedExpiresAtTimestamp: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				ExpiresAtTimestamp: tc.expiresAtTimestamp,
+				TTLSeconds:         tc.ttlSeconds,
+			}
+
+			env.RenewExpiry(now)
+
+			if env.ExpiresAtTimestamp != tc.expectedExpiresAtTimestamp {
+				t.Fatalf(
+					"expected expiry to equal %d, got %d",
+					tc.expectedExpiresAtTimestamp,
+					env.ExpiresAtTimestamp,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigFindExpiredEnvs(t *testing.T) {
+	now := time.Unix(100000, 0)
+	gracePeriod := 10 * time.Minute
+
+	expiredLongAgo := now.Add(-time.Hour).Unix()
+	expiredRecently := now.Add(-time.Minute).Unix()
+
+	config := NewConfig()
+	config.Clusters[DefaultClusterName] = &Cluster{
+		Name: DefaultClusterName,
+		Envs: map[string]*Env{
+			"no-expiry": {Name: "no-expiry", Status: EnvStatusCreated},
+			"in-grace":  {Name: "in-grace", Status: EnvStatusCreated, ExpiresAtTimestamp: expiredRecently},
+			"expired":   {Name: "expired", Status: EnvStatusCreated, ExpiresAtTimestamp: expiredLongAgo},
+			"stopped":   {Name: "stopped", Status: EnvStatusStopped, ExpiresAtTimestamp: expiredLongAgo},
+			"removing":  {Name: "removing", Status: EnvStatusRemoving, ExpiresAtTimestamp: expiredLongAgo},
+		},
+	}
+
+	testCases := []struct {
+		test             string
+		action           EnvExpiryAction
+		expectedEnvNames []string
+	}{
+		{
+			test:             "with stop action",
+			action:           EnvExpiryActionStop,
+			expectedEnvNames: []string{"expired"},
+		},
+
+		{
+			test:             "with remove action",
+			action:           EnvExpiryActionRemove,
+			expectedEnvNames: []string{"expired", "stopped"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			envs, err := config.FindExpiredEnvs(
+				DefaultClusterName,
+				tc.action,
+				gracePeriod,
+				now,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			envNames := []string{}
+			for _, env := range envs {
+				envNames = append(envNames, env.Name)
+			}
+
+			if len(envNames) != len(tc.expectedEnvNames) {
+				t.Fatalf(
+					"expected envs to equal '%+v', got '%+v'",
+					tc.expectedEnvNames,
+					envNames,
+				)
+			}
+
+			for envIndex := range envNames {
+				if envNames[envIndex] != tc.expectedEnvNames[envIndex] {
+					t.Fatalf(
+						"expected envs to equal '%+v', got '%+v'",
+						tc.expectedEnvNames,
+						envNames,
+					)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package entities
+
+// EnvGitHubSSHKeyScope defines how the SSH key
+// of an env is registered on GitHub.
+//
+// With the "user" scope, the key is added to the
+// user account and grants access to every repository
+// the user can reach. With deploy keys scopes, the key
+// is only registered on the env's repositories.
+type EnvGitHubSSHKeyScope string
+
+const (
+	EnvGitHubSSHKeyScopeUser                EnvGitHubSSHKeyScope = "user"
+	EnvGitHubSSHKeyScopeReadOnlyDeployKeys  EnvGitHubSSHKeyScope = "read_only_deploy_keys"
+	EnvGitHubSSHKeyScopeReadWriteDeployKeys EnvGitHubSSHKeyScope = "read_write_deploy_keys"
+)
+
+var envGitHubSSHKeyScopes = []EnvGitHubSSHKeyScope{
+	EnvGitHubSSHKeyScopeUser,
+	EnvGitHubSSHKeyScopeReadOnlyDeployKeys,
+	EnvGitHubSSHKeyScopeReadWriteDeployKeys,
+}
+
+func ParseEnvGitHubSSHKeyScope(scope string) (EnvGitHubSSHKeyScope, error) {
+	if len(scope) == 0 {
+		return EnvGitHubSSHKeyScopeUser, nil
+	}
+
+	for _, availableScope := range envGitHubSSHKeyScopes {
+		if EnvGitHubSSHKeyScope(scope) == availableScope {
+			return availableScope, nil
+		}
+	}
+
+	validScopes := []string{}
+	for _, availableScope := range envGitHubSSHKeyScopes {
+		validScopes = append(validScopes, string(availableScope))
+	}
+
+	return "", ErrInvalidGitHubSSHKeyScope{
+		Scope:       scope,
+		ValidScopes: validScopes,
+	}
+}
+
+func (e *Env) UsesDeployKeys() bool {
+	return e.GitHubSSHKeyScope == EnvGitHubSSHKeyScopeReadOnlyDeployKeys ||
+		e.GitHubSSHKeyScope == EnvGitHubSSHKeyScopeReadWriteDeployKeys
+}
+
+func (e *Env) HasReadOnlyDeployKeys() bool {
+	return e.GitHubSSHKeyScope == EnvGitHubSSHKeyScopeReadOnlyDeployKeys
+}
+
+// CheckDeployKeysRepositories returns an error if the env uses
+// deploy keys with more than one repository. GitHub rejects a deploy
+// key that is already registered on another repository.
+func (e *Env) CheckDeployKeysRepositories() error {
+	if !e.UsesDeployKeys() || len(e.Repositories) <= 1 {
+		return nil
+	}
+
+	return ErrDeployKeysMultipleRepositories{
+		EnvName:           e.Name,
+		RepositoriesCount: len(e.Repositories),
+	}
+}
@@ -0,0 +1,19 @@
+package entities
+
+type ErrInvalidGitHubSSHKeyScope struct {
+	Scope       string
+	ValidScopes []string
+}
+
+func (ErrInvalidGitHubSSHKeyScope) Error() string {
+	return "ErrInvalidGitHubSSHKeyScope"
+}
+
+type ErrDeployKeysMultipleRepositories struct {
+	EnvName           string
+	RepositoriesCount int
+}
+
+func (ErrDeployKeysMultipleRepositories) Error() string {
+	return "ErrDeployKeysMultipleRepositories"
+}
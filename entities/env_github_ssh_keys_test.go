@@ -0,0 +1,223 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseEnvGitHubSSHKeyScopeWithValidScopes(t *testing.T) {
+	testCases := []struct {
+		test          string
+		scope         string
+		expectedScope EnvGitHubSSHKeyScope
+	}{
+		{
+			test:          "with empty scope",
+			scope:         "",
+			expectedScope: EnvGitHubSSHKeyScopeUser,
+		},
+
+		{
+			test:          "with user scope",
+			scope:         "user",
+			expectedScope: EnvGitHubSSHKeyScopeUser,
+		},
+
+		{
+			test:          "with read-only deploy keys scope",
+			scope:         "read_only_deploy_keys",
+			expectedScope: EnvGitHubSSHKeyScopeReadOnlyDeployKeys,
+		},
+
+		{
+			test:          "with read-write deploy keys scope",
+			scope:         "read_write_deploy_keys",
+			expectedScope: EnvGitHubSSHKeyScopeReadWriteDeployKeys,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			scope, err := ParseEnvGitHubSSHKeyScope(tc.scope)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if scope != tc.expectedScope {
+				t.Fatalf(
+					"expected scope to equal '%s', got '%s'",
+					tc.expectedScope,
+					scope,
+				)
+			}
+		})
+	}
+}
+
+func TestParseEnvGitHubSSHKeyScopeWithInvalidScopes(t *testing.T) {
+	testCases := []struct {
+		test  string
+		scope string
+	}{
+		{
+			test:  "with unknown scope",
+			scope: "organization",
+		},
+
+		{
+			test:  "with uppercase scope",
+			scope: "USER",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseEnvGitHubSSHKeyScope(tc.scope)
+
+			if err == nil || !errors.As(err, &ErrInvalidGitHubSSHKeyScope{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					ErrInvalidGitHubSSHKeyScope{},
+					err,
+				)
+			}
+
+			typedError := err.(ErrInvalidGitHubSSHKeyScope)
+
+			if typedError.Scope != tc.scope {
+				t.Fatalf(
+					"expected scope to equal '%s', got '%s'",
+					tc.scope,
+					typedError.Scope,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvUsesDeployKeys(t *testing.T) {
+	testCases := []struct {
+		test                   string
+		scope                  EnvGitHubSSHKeyScope
+		expectedUsesDeployKeys bool
+		expectedReadOnly       bool
+	}{
+		{
+			test:                   "with empty scope",
+			scope:                  "",
+			expectedUsesDeployKeys: false,
+			expectedReadOnly:       false,
+		},
+
+		{
+			test:                   "with user scope",
+			scope:                  EnvGitHubSSHKeyScopeUser,
+			expectedUsesDeployKeys: false,
+			expectedReadOnly:       false,
+		},
+
+		{
+			test:                   "with read-only deploy keys scope",
+			scope:                  EnvGitHubSSHKeyScopeReadOnlyDeployKeys,
+			expectedUsesDeployKeys: true,
+			expectedReadOnly:       true,
+		},
+
+		{
+			test:                   "with read-write deploy keys scope",
+			scope:                  EnvGitHubSSHKeyScopeReadWriteDeployKeys,
+			expectedUsesDeployKeys: true,
+			expectedReadOnly:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				GitHubSSHKeyScope: tc.scope,
+			}
+
+			if env.UsesDeployKeys() != tc.expectedUsesDeployKeys {
+				t.Fatalf(
+					"expected uses deploy keys to equal '%v', got '%v'",
+					tc.expectedUsesDeployKeys,
+					env.UsesDeployKeys(),
+				)
+			}
+
+			if env.HasReadOnlyDeployKeys() != tc.expectedReadOnly {
+				t.Fatalf(
+					"expected read-only deploy keys to equal '%v', got '%v'",
+					tc.expectedReadOnly,
+					env.HasReadOnlyDeployKeys(),
+				)
+			}
+		})
+	}
+}
+
+func TestEnvCheckDeployKeysRepositories(t *testing.T) {
+	testCases := []struct {
+		test          string
+		scope         EnvGitHubSSHKeyScope
+		repositories  []EnvRepository
+		expectedError error
+	}{
+		{
+			test:  "with user scope and multiple repositories",
+			scope: EnvGitHubSSHKeyScopeUser,
+			repositories: []EnvRepository{
+				{Owner: "eleven-sh", Name: "cli"},
+				{Owner: "eleven-sh", Name: "eleven"},
+			},
+			expectedError: nil,
+		},
+
+		{
+			test:  "with deploy keys scope and one repository",
+			scope: EnvGitHubSSHKeyScopeReadOnlyDeployKeys,
+			repositories: []EnvRepository{
+				{Owner: "eleven-sh", Name: "cli"},
+			},
+			expectedError: nil,
+		},
+
+		{
+			test:  "with deploy keys scope and multiple repositories",
+			scope: EnvGitHubSSHKeyScopeReadWriteDeployKeys,
+			repositories: []EnvRepository{
+				{Owner: "eleven-sh", Name: "cli"},
+				{Owner: "eleven-sh", Name: "eleven"},
+			},
+			expectedError: ErrDeployKeysMultipleRepositories{
+				EnvName:           "env_name",
+				RepositoriesCount: 2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				Name:              "env_name",
+				GitHubSSHKeyScope: tc.scope,
+				Repositories:      tc.repositories,
+			}
+
+			err := env.CheckDeployKeysRepositories()
+
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package entities
+
+import "time"
+
+const IdlePolicyMinIdleTimeout = 15 * time.Minute
+
+// IdlePolicy stops the envs that had no activity during the
+// idle timeout. Envs policies override clusters ones.
+type IdlePolicy struct {
+	IdleTimeoutSeconds int64 `json:"idle_timeout_seconds"`
+	// Disabled policies let envs
+	// opt out of their cluster policy
+	Disabled bool `json:"disabled"`
+}
+
+func NewIdlePolicy(idleTimeout time.Duration) (*IdlePolicy, error) {
+	if idleTimeout < IdlePolicyMinIdleTimeout {
+		return nil, ErrInvalidIdleTimeout{
+			IdleTimeout:    idleTimeout,
+			MinIdleTimeout: IdlePolicyMinIdleTimeout,
+		}
+	}
+
+	return &IdlePolicy{
+		IdleTimeoutSeconds: int64(idleTimeout.Seconds()),
+	}, nil
+}
+
+func NewDisabledIdlePolicy() *IdlePolicy {
+	return &IdlePolicy{
+		Disabled: true,
+	}
+}
+
+// IsEnabled returns false for
+// nil and disabled policies
+func (i *IdlePolicy) IsEnabled() bool {
+	return i != nil && !i.Disabled
+}
+
+func (i *IdlePolicy) GetIdleTimeout() time.Duration {
+	return time.Duration(i.IdleTimeoutSeconds) * time.Second
+}
+
+// EnvActivity holds the last activity signals reported
+// by the cloud services. Zero times mean no activity reported.
+// CPU activity is reported when the usage is above the
+// threshold considered as idle by the cloud service.
+type EnvActivity struct {
+	LastSSHSessionAt      time.Time
+	LastCPUActivityAt     time.Time
+	LastNetworkActivityAt time.Time
+}
+
+// GetLastActivityAt returns the most recent activity signal.
+// Envs with no activity are considered active since their
+// last status change (e.g. their creation or their last start).
+func (e *Env) GetLastActivityAt(activity *EnvActivity) time.Time {
+	lastActivityAt := time.Unix(e.CreatedAtTimestamp, 0)
+
+	if e.StatusUpdatedAtTimestamp > e.CreatedAtTimestamp {
+		lastActivityAt = time.Unix(e.StatusUpdatedAtTimestamp, 0)
+	}
+
+	if activity == nil {
+		return lastActivityAt
+	}
+
+	for _, activityAt := range []time.Time{
+		activity.LastSSHSessionAt,
+		activity.LastCPUActivityAt,
+		activity.LastNetworkActivityAt,
+	} {
+
+		if activityAt.After(lastActivityAt) {
+			lastActivityAt = activityAt
+		}
+	}
+
+	return lastActivityAt
+}
+
+// GetEffectiveIdlePolicy returns the env policy if any or
+// the cluster one. Nil or disabled means no auto-stop.
+func (e *Env) GetEffectiveIdlePolicy(cluster *Cluster) *IdlePolicy {
+	if e.IdlePolicy != nil {
+		return e.IdlePolicy
+	}
+
+	return cluster.IdlePolicy
+}
+
+func (e *Env) IsIdle(
+	cluster *Cluster,
+	activity *EnvActivity,
+	now time.Time,
+) bool {
+
+	idlePolicy := e.GetEffectiveIdlePolicy(cluster)
+
+	if !idlePolicy.IsEnabled() {
+		return false
+	}
+
+	return now.Sub(e.GetLastActivityAt(activity)) > idlePolicy.GetIdleTimeout()
+}
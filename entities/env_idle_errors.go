@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type ErrInvalidIdleTimeout struct {
+	IdleTimeout    time.Duration
+	MinIdleTimeout time.Duration
+}
+
+func (ErrInvalidIdleTimeout) Error() string {
+	return "ErrInvalidIdleTimeout"
+}
+
+type ErrIdlePolicyRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrIdlePolicyRemovingEnv) Error() string {
+	return "ErrIdlePolicyRemovingEnv"
+}
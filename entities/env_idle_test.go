@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewIdlePolicy(t *testing.T) {
+	_, err := NewIdlePolicy(time.Minute)
+
+	if !errors.As(err, &ErrInvalidIdleTimeout{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidIdleTimeout{},
+			err,
+		)
+	}
+
+	idlePolicy, err := NewIdlePolicy(2 * time.Hour)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if idlePolicy.GetIdleTimeout() != 2*time.Hour {
+		t.Fatalf(
+			"expected idle timeout to equal '%s', got '%s'",
+			2*time.Hour,
+			idlePolicy.GetIdleTimeout(),
+		)
+	}
+}
+
+func TestEnvIsIdle(t *testing.T) {
+	now := time.Unix(100000, 0)
+	createdAt := now.Add(-24 * time.Hour).Unix()
+
+	clusterPolicy := &IdlePolicy{IdleTimeoutSeconds: int64((4 * time.Hour).Seconds())}
+	envPolicy := &IdlePolicy{IdleTimeoutSeconds: int64((1 * time.Hour).Seconds())}
+
+	testCases := []struct {
+		test                     string
+		clusterPolicy            *IdlePolicy
+		envPolicy                *IdlePolicy
+		activity                 *EnvActivity
+		statusUpdatedAtTimestamp int64
+		expectedIdle             bool
+	}{
+		{
+			test:         "with no policy",
+			activity:     &EnvActivity{},
+			expectedIdle: false,
+		},
+
+		{
+			test:          "with cluster policy and no activity",
+			clusterPolicy: clusterPolicy,
+			activity:      &EnvActivity{},
+			expectedIdle:  true,
+		},
+
+		{
+			test:          "with cluster policy and recent SSH session",
+			clusterPolicy: clusterPolicy,
+			activity: &EnvActivity{
+				LastSSHSessionAt: now.Add(-2 * time.Hour),
+			},
+			expectedIdle: false,
+		},
+
+		{
+			test:          "with env policy overriding cluster policy",
+			clusterPolicy: clusterPolicy,
+			envPolicy:     envPolicy,
+			activity: &EnvActivity{
+				LastNetworkActivityAt: now.Add(-2 * time.Hour),
+			},
+			expectedIdle: true,
+		},
+
+		{
+			test:         "with env policy and recent CPU activity",
+			envPolicy:    envPolicy,
+			activity:     &EnvActivity{LastCPUActivityAt: now.Add(-time.Minute)},
+			expectedIdle: false,
+		},
+
+		{
+			test:          "with disabled env policy overriding cluster policy",
+			clusterPolicy: clusterPolicy,
+			envPolicy:     NewDisabledIdlePolicy(),
+			activity:      &EnvActivity{},
+			expectedIdle:  false,
+		},
+
+		{
+			test:                     "with cluster policy and recent start",
+			clusterPolicy:            clusterPolicy,
+			activity:                 &EnvActivity{},
+			statusUpdatedAtTimestamp: now.Add(-time.Hour).Unix(),
+			expectedIdle:             false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			cluster := &Cluster{IdlePolicy: tc.clusterPolicy}
+			env := &Env{
+				IdlePolicy:               tc.envPolicy,
+				StatusUpdatedAtTimestamp: tc.statusUpdatedAtTimestamp,
+				CreatedAtTimestamp:       createdAt,
+			}
+
+			idle := env.IsIdle(cluster, tc.activity, now)
+
+			if idle != tc.expectedIdle {
+				t.Fatalf("expected idle to equal %t, got %t", tc.expectedIdle, idle)
+			}
+		})
+	}
+}
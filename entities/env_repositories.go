@@ -8,4 +8,5 @@ type EnvRepository struct {
 	ExplicitOwner bool                `json:"explicit_owner"`
 	GitURL        EnvRepositoryGitURL `json:"git_url"`
 	GitHTTPURL    EnvRepositoryGitURL `json:"git_http_url"`
+	DeployKeyID   int64               `json:"deploy_key_id"`
 }
@@ -0,0 +1,201 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const (
+	EnvSecretNameRegExp    = `^[A-Za-z_][A-Za-z0-9_]*$`
+	EnvSecretNameMaxLength = 128
+)
+
+// Secrets with these names would
+// break the shell of the sandbox
+var envReservedSecretNames = []string{
+	"HOME",
+	"PATH",
+	"PWD",
+	"SHELL",
+	"USER",
+}
+
+// EnvSecretScope defines where a secret is materialized
+// in the sandbox. Global secrets are exported in all shells
+// whereas repository secrets (scoped as "owner/name") are
+// written in the ".env" file of the repository.
+type EnvSecretScope string
+
+const EnvSecretScopeGlobal EnvSecretScope = "global"
+
+type EnvSecret struct {
+	Name               string         `json:"name"`
+	Value              string         `json:"value"`
+	Scope              EnvSecretScope `json:"scope"`
+	UpdatedAtTimestamp int64          `json:"updated_at_timestamp"`
+}
+
+type EnvSecrets map[string]*EnvSecret
+
+type EnvSecretsFile struct {
+	Scope   EnvSecretScope
+	Content string
+}
+
+func (e *Env) ParseSecretScope(scope string) (EnvSecretScope, error) {
+	if len(scope) == 0 || EnvSecretScope(scope) == EnvSecretScopeGlobal {
+		return EnvSecretScopeGlobal, nil
+	}
+
+	for _, repo := range e.Repositories {
+		if scope == repo.Owner+"/"+repo.Name {
+			return EnvSecretScope(scope), nil
+		}
+	}
+
+	return "", ErrInvalidEnvSecretScope{
+		Scope: scope,
+	}
+}
+
+func (e *Env) SecretExists(secretName string) bool {
+	_, secretExists := e.Secrets[secretName]
+	return secretExists
+}
+
+func (e *Env) SetSecret(
+	secretName string,
+	secretValue string,
+	scope EnvSecretScope,
+) error {
+
+	err := CheckEnvSecretNameValidity(secretName)
+
+	if err != nil {
+		return err
+	}
+
+	// Values looking encrypted would be stored in
+	// plaintext and would fail to decrypt afterward
+	if strings.HasPrefix(secretValue, EncryptedSensitiveFieldPrefix) {
+		return ErrReservedEnvSecretValue{
+			SecretName: secretName,
+		}
+	}
+
+	if e.Secrets == nil { // Envs created before secrets
+		e.Secrets = EnvSecrets{}
+	}
+
+	e.Secrets[secretName] = &EnvSecret{
+		Name:               secretName,
+		Value:              secretValue,
+		Scope:              scope,
+		UpdatedAtTimestamp: time.Now().Unix(),
+	}
+
+	return nil
+}
+
+func (e *Env) UnsetSecret(secretName string) error {
+	if !e.SecretExists(secretName) {
+		return ErrEnvSecretNotExists{
+			EnvName:    e.Name,
+			SecretName: secretName,
+		}
+	}
+
+	delete(e.Secrets, secretName)
+
+	return nil
+}
+
+// GetSortedSecrets returns the env's secrets sorted by name
+func (e *Env) GetSortedSecrets() []*EnvSecret {
+	secrets := []*EnvSecret{}
+
+	for _, secret := range e.Secrets {
+		secrets = append(secrets, secret)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	return secrets
+}
+
+// BuildSecretsFiles returns one dotenv file content per
+// scope. The files are materialized on the instance by the agent.
+func (e *Env) BuildSecretsFiles() []EnvSecretsFile {
+	contentByScope := map[EnvSecretScope]*strings.Builder{}
+	scopes := []EnvSecretScope{}
+
+	for _, secret := range e.GetSortedSecrets() {
+		if _, scopeExists := contentByScope[secret.Scope]; !scopeExists {
+			contentByScope[secret.Scope] = &strings.Builder{}
+			scopes = append(scopes, secret.Scope)
+		}
+
+		fmt.Fprintf(
+			contentByScope[secret.Scope],
+			"%s=\"%s\"\n",
+			secret.Name,
+			escapeDotenvValue(secret.Value),
+		)
+	}
+
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i] < scopes[j]
+	})
+
+	secretsFiles := []EnvSecretsFile{}
+
+	for _, scope := range scopes {
+		secretsFiles = append(secretsFiles, EnvSecretsFile{
+			Scope:   scope,
+			Content: contentByScope[scope].String(),
+		})
+	}
+
+	return secretsFiles
+}
+
+func escapeDotenvValue(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"$", `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+	).Replace(value)
+}
+
+func CheckEnvSecretNameValidity(secretName string) error {
+	validSecretName := govalidator.Matches(
+		secretName,
+		EnvSecretNameRegExp,
+	)
+
+	if !validSecretName || len(secretName) > EnvSecretNameMaxLength {
+		return ErrInvalidEnvSecretName{
+			SecretName:          secretName,
+			SecretNameRegExp:    EnvSecretNameRegExp,
+			SecretNameMaxLength: EnvSecretNameMaxLength,
+		}
+	}
+
+	for _, reservedSecretName := range envReservedSecretNames {
+		if strings.EqualFold(secretName, reservedSecretName) {
+			return ErrReservedEnvSecretName{
+				SecretName: secretName,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package entities
+
+type ErrInvalidEnvSecretName struct {
+	SecretName          string
+	SecretNameRegExp    string
+	SecretNameMaxLength int
+}
+
+func (ErrInvalidEnvSecretName) Error() string {
+	return "ErrInvalidEnvSecretName"
+}
+
+type ErrReservedEnvSecretName struct {
+	SecretName string
+}
+
+func (ErrReservedEnvSecretName) Error() string {
+	return "ErrReservedEnvSecretName"
+}
+
+type ErrReservedEnvSecretValue struct {
+	SecretName string
+}
+
+func (ErrReservedEnvSecretValue) Error() string {
+	return "ErrReservedEnvSecretValue"
+}
+
+type ErrInvalidEnvSecretScope struct {
+	Scope string
+}
+
+func (ErrInvalidEnvSecretScope) Error() string {
+	return "ErrInvalidEnvSecretScope"
+}
+
+type ErrEnvSecretNotExists struct {
+	EnvName    string
+	SecretName string
+}
+
+func (ErrEnvSecretNotExists) Error() string {
+	return "ErrEnvSecretNotExists"
+}
+
+type ErrSecretsRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrSecretsRemovingEnv) Error() string {
+	return "ErrSecretsRemovingEnv"
+}
+
+type ErrSecretsCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrSecretsCreatingEnv) Error() string {
+	return "ErrSecretsCreatingEnv"
+}
@@ -0,0 +1,299 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckEnvSecretNameValidityWithValidNames(t *testing.T) {
+	testCases := []struct {
+		test       string
+		secretName string
+	}{
+		{
+			test:       "with uppercase name",
+			secretName: "API_KEY",
+		},
+
+		{
+			test:       "with lowercase name",
+			secretName: "api_key",
+		},
+
+		{
+			test:       "with leading underscore",
+			secretName: "_API_KEY_2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckEnvSecretNameValidity(tc.secretName)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+		})
+	}
+}
+
+func TestCheckEnvSecretNameValidityWithInvalidNames(t *testing.T) {
+	testCases := []struct {
+		test          string
+		secretName    string
+		expectedError error
+	}{
+		{
+			test:          "with empty name",
+			secretName:    "",
+			expectedError: ErrInvalidEnvSecretName{},
+		},
+
+		{
+			test:          "with leading digit",
+			secretName:    "2_API_KEY",
+			expectedError: ErrInvalidEnvSecretName{},
+		},
+
+		{
+			test:          "with dash",
+			secretName:    "API-KEY",
+			expectedError: ErrInvalidEnvSecretName{},
+		},
+
+		{
+			test:          "with too long name",
+			secretName:    string(make([]byte, EnvSecretNameMaxLength+1)),
+			expectedError: ErrInvalidEnvSecretName{},
+		},
+
+		{
+			test:          "with reserved name",
+			secretName:    "PATH",
+			expectedError: ErrReservedEnvSecretName{},
+		},
+
+		{
+			test:          "with lowercase reserved name",
+			secretName:    "home",
+			expectedError: ErrReservedEnvSecretName{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckEnvSecretNameValidity(tc.secretName)
+
+			if err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%T', got '%T'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvParseSecretScope(t *testing.T) {
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{
+			{
+				Owner: "eleven-sh",
+				Name:  "cli",
+			},
+		},
+		EnvRuntimes{},
+	)
+
+	testCases := []struct {
+		test          string
+		scope         string
+		expectedScope EnvSecretScope
+		expectedError bool
+	}{
+		{
+			test:          "with empty scope",
+			scope:         "",
+			expectedScope: EnvSecretScopeGlobal,
+		},
+
+		{
+			test:          "with global scope",
+			scope:         "global",
+			expectedScope: EnvSecretScopeGlobal,
+		},
+
+		{
+			test:          "with env repository",
+			scope:         "eleven-sh/cli",
+			expectedScope: EnvSecretScope("eleven-sh/cli"),
+		},
+
+		{
+			test:          "with unknown repository",
+			scope:         "eleven-sh/eleven",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			scope, err := env.ParseSecretScope(tc.scope)
+
+			if tc.expectedError {
+				if err == nil || !errors.As(err, &ErrInvalidEnvSecretScope{}) {
+					t.Fatalf(
+						"expected error to equal '%+v', got '%+v'",
+						ErrInvalidEnvSecretScope{},
+						err,
+					)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if scope != tc.expectedScope {
+				t.Fatalf(
+					"expected scope to equal '%s', got '%s'",
+					tc.expectedScope,
+					scope,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvSetAndUnsetSecret(t *testing.T) {
+	// Envs created before secrets have a nil map
+	env := &Env{
+		Name: "env_name",
+	}
+
+	err := env.SetSecret("invalid-name", "value", EnvSecretScopeGlobal)
+
+	if err == nil || !errors.As(err, &ErrInvalidEnvSecretName{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidEnvSecretName{},
+			err,
+		)
+	}
+
+	err = env.SetSecret(
+		"API_KEY",
+		EncryptedSensitiveFieldPrefix+"value",
+		EnvSecretScopeGlobal,
+	)
+
+	if err == nil || !errors.As(err, &ErrReservedEnvSecretValue{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrReservedEnvSecretValue{},
+			err,
+		)
+	}
+
+	err = env.SetSecret("API_KEY", "value", EnvSecretScopeGlobal)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !env.SecretExists("API_KEY") || env.Secrets["API_KEY"].Value != "value" {
+		t.Fatalf("expected secret to be set")
+	}
+
+	err = env.SetSecret("API_KEY", "new_value", EnvSecretScopeGlobal)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.Secrets["API_KEY"].Value != "new_value" {
+		t.Fatalf("expected secret to be updated")
+	}
+
+	err = env.UnsetSecret("API_KEY")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.SecretExists("API_KEY") {
+		t.Fatalf("expected secret to be unset")
+	}
+
+	err = env.UnsetSecret("API_KEY")
+
+	if err == nil || !errors.As(err, &ErrEnvSecretNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrEnvSecretNotExists{},
+			err,
+		)
+	}
+}
+
+func TestEnvBuildSecretsFiles(t *testing.T) {
+	env := &Env{
+		Secrets: EnvSecrets{
+			"B_SECRET": {
+				Name:  "B_SECRET",
+				Value: "b",
+				Scope: EnvSecretScopeGlobal,
+			},
+
+			"A_SECRET": {
+				Name:  "A_SECRET",
+				Value: "with \"quotes\", $vars, `cmds` and\nnew lines\\",
+				Scope: EnvSecretScopeGlobal,
+			},
+
+			"REPO_SECRET": {
+				Name:  "REPO_SECRET",
+				Value: "repo",
+				Scope: EnvSecretScope("eleven-sh/cli"),
+			},
+		},
+	}
+
+	expectedSecretsFiles := []EnvSecretsFile{
+		{
+			Scope:   EnvSecretScope("eleven-sh/cli"),
+			Content: "REPO_SECRET=\"repo\"\n",
+		},
+
+		{
+			Scope: EnvSecretScopeGlobal,
+			Content: "A_SECRET=\"with \\\"quotes\\\", \\$vars, \\`cmds\\` and\\nnew lines\\\\\"\n" +
+				"B_SECRET=\"b\"\n",
+		},
+	}
+
+	secretsFiles := env.BuildSecretsFiles()
+
+	if !reflect.DeepEqual(expectedSecretsFiles, secretsFiles) {
+		t.Fatalf(
+			"expected secrets files to equal '%+v', got '%+v'",
+			expectedSecretsFiles,
+			secretsFiles,
+		)
+	}
+
+	if len((&Env{}).BuildSecretsFiles()) != 0 {
+		t.Fatalf("expected no secrets files without secrets")
+	}
+}
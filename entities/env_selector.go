@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"path"
+	"time"
+)
+
+// EnvSelector selects the envs targeted by bulk operations.
+// An env is selected when it matches all the set criteria.
+type EnvSelector struct {
+	Names []string
+	// NamePattern is a glob pattern (e.g. "api-*")
+	NamePattern string
+	Statuses    []EnvStatus
+	// MinAge selects the envs created
+	// for at least the passed duration
+	MinAge time.Duration
+	Labels Labels
+}
+
+func (e EnvSelector) IsEmpty() bool {
+	return len(e.Names) == 0 &&
+		len(e.NamePattern) == 0 &&
+		len(e.Statuses) == 0 &&
+		e.MinAge <= 0 &&
+		len(e.Labels) == 0
+}
+
+// CheckEnvSelectorValidity prevents bulk operations
+// from targeting all the envs by mistake.
+func CheckEnvSelectorValidity(selector EnvSelector) error {
+	if selector.IsEmpty() {
+		return ErrEmptyEnvSelector
+	}
+
+	if len(selector.NamePattern) > 0 {
+		_, err := path.Match(selector.NamePattern, "")
+
+		if err != nil {
+			return ErrInvalidEnvSelectorNamePattern{
+				NamePattern: selector.NamePattern,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e EnvSelector) Matches(env *Env, now time.Time) bool {
+	if len(e.Names) > 0 && !containsString(e.Names, env.Name) {
+		return false
+	}
+
+	if len(e.NamePattern) > 0 {
+		matched, err := path.Match(e.NamePattern, env.Name)
+
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	if len(e.Statuses) > 0 {
+		statusMatched := false
+
+		for _, status := range e.Statuses {
+			if env.Status == status {
+				statusMatched = true
+				break
+			}
+		}
+
+		if !statusMatched {
+			return false
+		}
+	}
+
+	if e.MinAge > 0 && !isOlderThan(env.CreatedAtTimestamp, e.MinAge, now) {
+		return false
+	}
+
+	if !env.Labels.Matches(e.Labels) {
+		return false
+	}
+
+	return true
+}
+
+// SelectEnvs returns the envs of the passed
+// cluster that match the selector, sorted by name.
+func (c *Config) SelectEnvs(
+	clusterName string,
+	selector EnvSelector,
+	now time.Time,
+) ([]*Env, error) {
+
+	cluster, err := c.GetCluster(clusterName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	selectedEnvs := []*Env{}
+
+	for _, env := range cluster.GetSortedEnvs() {
+		if !selector.Matches(env, now) {
+			continue
+		}
+
+		selectedEnvs = append(selectedEnvs, env)
+	}
+
+	return selectedEnvs, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+package entities
+
+type ErrInvalidEnvSelectorNamePattern struct {
+	NamePattern string
+}
+
+func (ErrInvalidEnvSelectorNamePattern) Error() string {
+	return "ErrInvalidEnvSelectorNamePattern"
+}
+
+type ErrBulkEnvOperationFailed struct {
+	NbOfFailedEnvs int
+	NbOfEnvs       int
+}
+
+func (ErrBulkEnvOperationFailed) Error() string {
+	return "ErrBulkEnvOperationFailed"
+}
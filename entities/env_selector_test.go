@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckEnvSelectorValidity(t *testing.T) {
+	testCases := []struct {
+		test          string
+		selector      EnvSelector
+		expectedError error
+	}{
+		{
+			test:          "with empty selector",
+			selector:      EnvSelector{},
+			expectedError: ErrEmptyEnvSelector,
+		},
+
+		{
+			test:          "with invalid name pattern",
+			selector:      EnvSelector{NamePattern: "api-["},
+			expectedError: ErrInvalidEnvSelectorNamePattern{NamePattern: "api-["},
+		},
+
+		{
+			test:          "with valid selector",
+			selector:      EnvSelector{NamePattern: "api-*"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckEnvSelectorValidity(tc.selector)
+
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigSelectEnvs(t *testing.T) {
+	now := time.Unix(100000, 0)
+	longAgo := now.Add(-48 * time.Hour).Unix()
+	recently := now.Add(-1 * time.Minute).Unix()
+
+	config := NewConfig()
+	config.Clusters[DefaultClusterName] = &Cluster{
+		Name: DefaultClusterName,
+		Envs: map[string]*Env{
+			"api-1": {Name: "api-1", Status: EnvStatusCreated, CreatedAtTimestamp: longAgo, Labels: Labels{"team": "api"}},
+			"api-2": {Name: "api-2", Status: EnvStatusCreating, CreatedAtTimestamp: recently},
+			"web":   {Name: "web", Status: EnvStatusCreated, CreatedAtTimestamp: recently},
+		},
+	}
+
+	testCases := []struct {
+		test             string
+		selector         EnvSelector
+		expectedEnvNames []string
+	}{
+		{
+			test:             "with names",
+			selector:         EnvSelector{Names: []string{"web", "api-1", "unknown"}},
+			expectedEnvNames: []string{"api-1", "web"},
+		},
+
+		{
+			test:             "with name pattern",
+			selector:         EnvSelector{NamePattern: "api-*"},
+			expectedEnvNames: []string{"api-1", "api-2"},
+		},
+
+		{
+			test:             "with statuses",
+			selector:         EnvSelector{Statuses: []EnvStatus{EnvStatusCreated}},
+			expectedEnvNames: []string{"api-1", "web"},
+		},
+
+		{
+			test:             "with min age",
+			selector:         EnvSelector{MinAge: 24 * time.Hour},
+			expectedEnvNames: []string{"api-1"},
+		},
+
+		{
+			test:             "with labels",
+			selector:         EnvSelector{Labels: Labels{"team": "api"}},
+			expectedEnvNames: []string{"api-1"},
+		},
+
+		{
+			test: "with multiple criteria",
+			selector: EnvSelector{
+				NamePattern: "api-*",
+				Statuses:    []EnvStatus{EnvStatusCreating},
+			},
+			expectedEnvNames: []string{"api-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			envs, err := config.SelectEnvs(DefaultClusterName, tc.selector, now)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			envNames := []string{}
+			for _, env := range envs {
+				envNames = append(envNames, env.Name)
+			}
+
+			if len(envNames) != len(tc.expectedEnvNames) {
+				t.Fatalf(
+					"expected envs to equal '%+v', got '%+v'",
+					tc.expectedEnvNames,
+					envNames,
+				)
+			}
+
+			for envIndex := range envNames {
+				if envNames[envIndex] != tc.expectedEnvNames[envIndex] {
+					t.Fatalf(
+						"expected envs to equal '%+v', got '%+v'",
+						tc.expectedEnvNames,
+						envNames,
+					)
+				}
+			}
+		})
+	}
+
+	_, err := config.SelectEnvs("unknown", EnvSelector{NamePattern: "*"}, now)
+
+	if !errors.As(err, &ErrClusterNotExists{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrClusterNotExists{},
+			err,
+		)
+	}
+}
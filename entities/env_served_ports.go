@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"sort"
+
 	"github.com/asaskevich/govalidator"
 )
 
@@ -20,6 +22,20 @@ const (
 	EnvServedPortBindingTypeDomain EnvServedPortBindingType = "domain"
 )
 
+func (s EnvServedPorts) GetSortedPorts() []EnvServedPort {
+	ports := []EnvServedPort{}
+
+	for port := range s {
+		ports = append(ports, port)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+
+	return ports
+}
+
 func (e *Env) DoesServedPortExist(servedPort EnvServedPort) bool {
 	_, servedPortExists := e.ServedPorts[servedPort]
 	return servedPortExists
@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+)
+
+const SSHKeyPairRSABits = 4096
+
+// EnvViewerUsername is the unprivileged user that viewers
+// are logged in as. It can't use sudo and has no write
+// access to the env's repositories and home directory.
+const EnvViewerUsername = "eleven-viewer"
+
+// EnvAuthorizedKeyViewerOptions are the authorized_keys options
+// set on the keys of viewers. "restrict" disables the port,
+// agent and X11 forwarding and the execution of ~/.ssh/rc.
+// The forced command ignores the command sent by the viewer
+// and opens a login shell as EnvViewerUsername instead so
+// that viewers can only run commands without privileges.
+const EnvAuthorizedKeyViewerOptions = `restrict,pty,command="sudo --non-interactive --user=` +
+	EnvViewerUsername + ` --login"`
+
+// EnvAuthorizedKey is a public key allowed to connect to an
+// env's instance. Options are prepended to the key in the
+// authorized_keys file to enforce the role.
+type EnvAuthorizedKey struct {
+	PublicKey string              `json:"public_key"`
+	Role      EnvCollaboratorRole `json:"role"`
+	Options   string              `json:"options"`
+	// Username is empty for the env's own keys
+	Username string `json:"username"`
+}
+
+// BuildAuthorizedKeysLine returns the line
+// to add to the authorized_keys file
+func (k EnvAuthorizedKey) BuildAuthorizedKeysLine() string {
+	publicKey := strings.TrimSpace(k.PublicKey)
+
+	if len(k.Options) == 0 {
+		return publicKey
+	}
+
+	return k.Options + " " + publicKey
+}
+
+func GenerateSSHKeyPairPEMContent() (string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, SSHKeyPairRSABits)
+
+	if err != nil {
+		return "", err
+	}
+
+	privateKeyPEMContent := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	return string(privateKeyPEMContent), nil
+}
+
+func (e *Env) IsRotatingSSHKey() bool {
+	return len(e.PendingSSHKeyPairPEMContent) > 0
+}
+
+func (e *Env) StartSSHKeyRotation(newSSHKeyPairPEMContent string) {
+	e.PendingSSHKeyPairPEMContent = newSSHKeyPairPEMContent
+}
+
+func (e *Env) ConfirmSSHKeyRotation() {
+	if !e.IsRotatingSSHKey() {
+		return
+	}
+
+	e.SSHKeyPairPEMContent = e.PendingSSHKeyPairPEMContent
+	e.PendingSSHKeyPairPEMContent = ""
+}
+
+func (e *Env) CancelSSHKeyRotation() {
+	e.PendingSSHKeyPairPEMContent = ""
+}
+
+// BuildAuthorizedKeys returns the public keys that must be
+// allowed to connect to the env's instance. During a key
+// rotation, both the current and the pending keys are returned
+// so that the old key stays valid until the switch is confirmed.
+// The keys of the collaborators are returned after the env's ones.
+func (e *Env) BuildAuthorizedKeys() ([]EnvAuthorizedKey, error) {
+	authorizedKeys := []EnvAuthorizedKey{}
+
+	sshKeyPairsPEMContent := []string{
+		e.SSHKeyPairPEMContent,
+		e.PendingSSHKeyPairPEMContent,
+	}
+
+	for _, sshKeyPairPEMContent := range sshKeyPairsPEMContent {
+		if len(sshKeyPairPEMContent) == 0 {
+			continue
+		}
+
+		publicKeyContent, err := BuildSSHPublicKeyContent(sshKeyPairPEMContent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		authorizedKeys = append(authorizedKeys, EnvAuthorizedKey{
+			PublicKey: publicKeyContent,
+			Role:      EnvCollaboratorRoleEditor,
+		})
+	}
+
+	for _, collaborator := range e.GetSortedCollaborators() {
+		options := ""
+
+		if collaborator.Role != EnvCollaboratorRoleEditor {
+			options = EnvAuthorizedKeyViewerOptions
+		}
+
+		for _, publicKey := range collaborator.PublicKeys {
+			authorizedKeys = append(authorizedKeys, EnvAuthorizedKey{
+				PublicKey: publicKey,
+				Role:      collaborator.Role,
+				Options:   options,
+				Username:  collaborator.Username,
+			})
+		}
+	}
+
+	return authorizedKeys, nil
+}
+
+// BuildAuthorizedKeysFileContent returns the content of the
+// authorized_keys file that cloud services must write
+// on the env's instance. See BuildAuthorizedKeys.
+func (e *Env) BuildAuthorizedKeysFileContent() (string, error) {
+	authorizedKeys, err := e.BuildAuthorizedKeys()
+
+	if err != nil {
+		return "", err
+	}
+
+	fileContent := ""
+
+	for _, authorizedKey := range authorizedKeys {
+		fileContent += authorizedKey.BuildAuthorizedKeysLine() + "\n"
+	}
+
+	return fileContent, nil
+}
@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSSHKeyPairPEMContent(t *testing.T) {
+	sshKeyPairPEMContent, err := GenerateSSHKeyPairPEMContent()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	_, err = BuildSSHPublicKeyContent(sshKeyPairPEMContent)
+
+	if err != nil {
+		t.Fatalf("expected parsable key pair, got '%+v'", err)
+	}
+}
+
+func TestEnvSSHKeyRotation(t *testing.T) {
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	oldSSHKeyPairPEMContent, _ := generateTestSSHKeyPair(t)
+	newSSHKeyPairPEMContent, _ := generateTestSSHKeyPair(t)
+
+	env.SSHKeyPairPEMContent = oldSSHKeyPairPEMContent
+
+	if env.IsRotatingSSHKey() {
+		t.Fatalf("expected env to not rotate SSH key")
+	}
+
+	env.StartSSHKeyRotation(newSSHKeyPairPEMContent)
+
+	if !env.IsRotatingSSHKey() {
+		t.Fatalf("expected env to rotate SSH key")
+	}
+
+	env.CancelSSHKeyRotation()
+
+	if env.IsRotatingSSHKey() ||
+		env.SSHKeyPairPEMContent != oldSSHKeyPairPEMContent {
+
+		t.Fatalf("expected SSH key rotation to be cancelled")
+	}
+
+	env.StartSSHKeyRotation(newSSHKeyPairPEMContent)
+	env.ConfirmSSHKeyRotation()
+
+	if env.IsRotatingSSHKey() ||
+		env.SSHKeyPairPEMContent != newSSHKeyPairPEMContent {
+
+		t.Fatalf("expected SSH key rotation to be confirmed")
+	}
+
+	// Confirming without pending key is a no-op
+	env.ConfirmSSHKeyRotation()
+
+	if env.SSHKeyPairPEMContent != newSSHKeyPairPEMContent {
+		t.Fatalf("expected SSH key to be kept")
+	}
+}
+
+func TestEnvBuildAuthorizedKeys(t *testing.T) {
+	oldSSHKeyPairPEMContent, oldPublicKeyContent := generateTestSSHKeyPair(t)
+	newSSHKeyPairPEMContent, newPublicKeyContent := generateTestSSHKeyPair(t)
+
+	testCases := []struct {
+		test                   string
+		sshKeyPairPEMContent   string
+		pendingPEMContent      string
+		collaborators          EnvCollaborators
+		expectedAuthorizedKeys []EnvAuthorizedKey
+		expectedError          bool
+	}{
+		{
+			test:                   "without SSH key",
+			expectedAuthorizedKeys: []EnvAuthorizedKey{},
+		},
+
+		{
+			test:                 "without rotation",
+			sshKeyPairPEMContent: oldSSHKeyPairPEMContent,
+			expectedAuthorizedKeys: []EnvAuthorizedKey{
+				{PublicKey: oldPublicKeyContent, Role: EnvCollaboratorRoleEditor},
+			},
+		},
+
+		{
+			test:                 "during rotation",
+			sshKeyPairPEMContent: oldSSHKeyPairPEMContent,
+			pendingPEMContent:    newSSHKeyPairPEMContent,
+			expectedAuthorizedKeys: []EnvAuthorizedKey{
+				{PublicKey: oldPublicKeyContent, Role: EnvCollaboratorRoleEditor},
+				{PublicKey: newPublicKeyContent, Role: EnvCollaboratorRoleEditor},
+			},
+		},
+
+		{
+			test:                 "with collaborators",
+			sshKeyPairPEMContent: oldSSHKeyPairPEMContent,
+			collaborators: EnvCollaborators{
+				"zoe": {
+					Username:   "Zoe",
+					Role:       EnvCollaboratorRoleEditor,
+					PublicKeys: []string{"ssh-ed25519 zoe_key"},
+				},
+				"bob": {
+					Username:   "bob",
+					Role:       EnvCollaboratorRoleViewer,
+					PublicKeys: []string{"ssh-rsa bob_key_1", "ssh-rsa bob_key_2"},
+				},
+			},
+			expectedAuthorizedKeys: []EnvAuthorizedKey{
+				{PublicKey: oldPublicKeyContent, Role: EnvCollaboratorRoleEditor},
+				{PublicKey: "ssh-rsa bob_key_1", Role: EnvCollaboratorRoleViewer, Options: EnvAuthorizedKeyViewerOptions, Username: "bob"},
+				{PublicKey: "ssh-rsa bob_key_2", Role: EnvCollaboratorRoleViewer, Options: EnvAuthorizedKeyViewerOptions, Username: "bob"},
+				{PublicKey: "ssh-ed25519 zoe_key", Role: EnvCollaboratorRoleEditor, Username: "Zoe"},
+			},
+		},
+
+		{
+			test:                 "with invalid pending key",
+			sshKeyPairPEMContent: oldSSHKeyPairPEMContent,
+			pendingPEMContent:    "invalid_pem_content",
+			expectedError:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				SSHKeyPairPEMContent:        tc.sshKeyPairPEMContent,
+				PendingSSHKeyPairPEMContent: tc.pendingPEMContent,
+				Collaborators:               tc.collaborators,
+			}
+
+			authorizedKeys, err := env.BuildAuthorizedKeys()
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got nothing")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedAuthorizedKeys, authorizedKeys) {
+				t.Fatalf(
+					"expected authorized keys to equal '%+v', got '%+v'",
+					tc.expectedAuthorizedKeys,
+					authorizedKeys,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvBuildAuthorizedKeysFileContent(t *testing.T) {
+	env := &Env{
+		Collaborators: EnvCollaborators{
+			"zoe": {
+				Username:   "Zoe",
+				Role:       EnvCollaboratorRoleEditor,
+				PublicKeys: []string{"ssh-ed25519 zoe_key\n"},
+			},
+			"bob": {
+				Username:   "bob",
+				Role:       EnvCollaboratorRoleViewer,
+				PublicKeys: []string{"ssh-rsa bob_key"},
+			},
+		},
+	}
+
+	fileContent, err := env.BuildAuthorizedKeysFileContent()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedFileContent := EnvAuthorizedKeyViewerOptions +
+		" ssh-rsa bob_key\nssh-ed25519 zoe_key\n"
+
+	if fileContent != expectedFileContent {
+		t.Fatalf(
+			"expected file content to equal '%s', got '%s'",
+			expectedFileContent,
+			fileContent,
+		)
+	}
+}
+
+func TestEnvAuthorizedKeyViewerOptions(t *testing.T) {
+	options := strings.Split(
+		strings.SplitN(EnvAuthorizedKeyViewerOptions, `command="`, 2)[0],
+		",",
+	)
+
+	if options[0] != "restrict" {
+		t.Fatalf("expected viewer keys to be restricted, got '%s'", options[0])
+	}
+
+	forcedCommand := strings.TrimPrefix(
+		EnvAuthorizedKeyViewerOptions,
+		strings.Join(options, ","),
+	)
+
+	// The command sent by the viewer must never be run as is
+	if !strings.HasPrefix(forcedCommand, `command="`) ||
+		strings.Contains(forcedCommand, "SSH_ORIGINAL_COMMAND") {
+
+		t.Fatalf("expected viewer keys to have a forced command, got '%s'", forcedCommand)
+	}
+
+	if !strings.Contains(forcedCommand, "--user="+EnvViewerUsername+" ") {
+		t.Fatalf(
+			"expected forced command to log viewers in as '%s', got '%s'",
+			EnvViewerUsername,
+			forcedCommand,
+		)
+	}
+}
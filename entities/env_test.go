@@ -1,11 +1,17 @@
 package entities
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	"golang.org/x/crypto/ssh"
 )
 
 func TestEnvGetNameSlug(t *testing.T) {
@@ -108,6 +114,106 @@ func TestEnvGetSSHKeyPairName(t *testing.T) {
 	}
 }
 
+func generateTestSSHKeyPair(t *testing.T) (string, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	privateKeyPEMContent := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	publicKeyContent := strings.TrimSpace(
+		string(ssh.MarshalAuthorizedKey(publicKey)),
+	)
+
+	return string(privateKeyPEMContent), publicKeyContent
+}
+
+func TestEnvGetSSHPublicKeyContent(t *testing.T) {
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+
+	_, err := env.GetSSHPublicKeyContent()
+
+	if err == nil || !errors.As(err, &ErrEnvSSHKeyPairNotGenerated{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrEnvSSHKeyPairNotGenerated{},
+			err,
+		)
+	}
+
+	env.SSHKeyPairPEMContent = "invalid_pem_content"
+	_, err = env.GetSSHPublicKeyContent()
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+
+	privateKeyPEMContent, expectedPublicKeyContent := generateTestSSHKeyPair(t)
+
+	env.SSHKeyPairPEMContent = privateKeyPEMContent
+	publicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if publicKeyContent != expectedPublicKeyContent {
+		t.Fatalf(
+			"expected public key to equal '%s', got '%s'",
+			expectedPublicKeyContent,
+			publicKeyContent,
+		)
+	}
+}
+
+func TestEnvClone(t *testing.T) {
+	env := NewEnv(
+		"env_name",
+		0,
+		"instance_type",
+		[]EnvRepository{},
+		EnvRuntimes{},
+	)
+	env.AddServedPortBinding("8080", "8080", false)
+
+	clonedEnv, err := env.Clone()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(env, clonedEnv) {
+		t.Fatalf(
+			"expected cloned env to equal '%+v', got '%+v'",
+			env,
+			clonedEnv,
+		)
+	}
+
+	clonedEnv.RemoveServedPort("8080")
+
+	if !env.DoesServedPortExist("8080") {
+		t.Fatalf("expected env to be unchanged when modifying its clone")
+	}
+}
+
 func TestEnvSetInfrastructureJSON(t *testing.T) {
 	type envInfra struct {
 		InstanceID string
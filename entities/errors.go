@@ -3,6 +3,13 @@ package entities
 import "errors"
 
 var (
-	ErrElevenNotInstalled    = errors.New("ErrElevenNotInstalled")
-	ErrUninstallExistingEnvs = errors.New("ErrUninstallExistingEnvs")
+	ErrElevenNotInstalled       = errors.New("ErrElevenNotInstalled")
+	ErrUninstallExistingEnvs    = errors.New("ErrUninstallExistingEnvs")
+	ErrImportRedactedConfig     = errors.New("ErrImportRedactedConfig")
+	ErrMissingExportKeyProvider = errors.New("ErrMissingExportKeyProvider")
+	ErrEmptyEnvSelector         = errors.New("ErrEmptyEnvSelector")
+	ErrInvalidConfigQuotas      = errors.New("ErrInvalidConfigQuotas")
+	ErrNoConfigAdmin            = errors.New("ErrNoConfigAdmin")
+	ErrQuotaOwnerRequired       = errors.New("ErrQuotaOwnerRequired")
+	ErrConfigTrailingData       = errors.New("ErrConfigTrailingData")
 )
@@ -9,9 +9,82 @@ type HookRunner interface {
 	) error
 }
 
+// HookRunners runs multiple hooks sequentially
+// and stops at the first returned error.
+type HookRunners []HookRunner
+
+func (h HookRunners) Run(
+	cloudService CloudService,
+	config *Config,
+	cluster *Cluster,
+	env *Env,
+) error {
+
+	for _, hookRunner := range h {
+		if hookRunner == nil {
+			continue
+		}
+
+		err := hookRunner.Run(
+			cloudService,
+			config,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type DomainReachabilityChecker interface {
 	Check(
 		env *Env,
 		domain string,
 	) (reachable bool, redirToHTTPS bool, err error)
 }
+
+type GitHubSSHKeySwapper interface {
+	Swap(
+		env *Env,
+		oldPublicKeyContent string,
+		newPublicKeyContent string,
+	) error
+}
+
+// GitHubSSHKeyRenamer is called after an env rename to keep
+// the GitHub key of the env registered. The access to
+// GitHub must never be interrupted during the rename.
+type GitHubSSHKeyRenamer interface {
+	Rename(
+		env *Env,
+		oldKeyPairName string,
+	) error
+}
+
+// GitHubPublicKeysFetcher returns the SSH public
+// keys registered on a GitHub account.
+type GitHubPublicKeysFetcher interface {
+	Fetch(username string) ([]string, error)
+}
+
+// EnvHealthChecker checks that an env
+// is reachable and ready to be used.
+type EnvHealthChecker interface {
+	Check(env *Env) (healthy bool, err error)
+}
+
+// ActorResolver returns the identity (e.g. the GitHub
+// username) of the person running the features.
+type ActorResolver interface {
+	ResolveActor() (string, error)
+}
+
+// GitHubDeployKeysCreator registers the SSH public key
+// of an env as a deploy key on the env's repositories.
+type GitHubDeployKeysCreator interface {
+	Create(env *Env) error
+}
@@ -0,0 +1,23 @@
+package entities
+
+type ErrPreHookFailed struct {
+	Event HookEvent
+	Err   error
+}
+
+func (ErrPreHookFailed) Error() string {
+	return "ErrPreHookFailed"
+}
+
+func (e ErrPreHookFailed) Unwrap() error {
+	return e.Err
+}
+
+type ErrPostHooksFailed struct {
+	Event HookEvent
+	Errs  []error
+}
+
+func (ErrPostHooksFailed) Error() string {
+	return "ErrPostHooksFailed"
+}
@@ -0,0 +1,150 @@
+package entities
+
+type HookEvent string
+
+const (
+	HookEventPreInit           HookEvent = "pre_init"
+	HookEventPostInit          HookEvent = "post_init"
+	HookEventEnvCreated        HookEvent = "env_created"
+	HookEventPreServe          HookEvent = "pre_serve"
+	HookEventPostServe         HookEvent = "post_serve"
+	HookEventPreUnserve        HookEvent = "pre_unserve"
+	HookEventPostUnserve       HookEvent = "post_unserve"
+	HookEventPreRemove         HookEvent = "pre_remove"
+	HookEventPostRemove        HookEvent = "post_remove"
+	HookEventPreUninstall      HookEvent = "pre_uninstall"
+	HookEventPostUninstall     HookEvent = "post_uninstall"
+	HookEventPreClusterCreate  HookEvent = "pre_cluster_create"
+	HookEventPostClusterCreate HookEvent = "post_cluster_create"
+	HookEventPreClusterRemove  HookEvent = "pre_cluster_remove"
+	HookEventPostClusterRemove HookEvent = "post_cluster_remove"
+)
+
+var preHookEvents = map[HookEvent]bool{
+	HookEventPreInit:          true,
+	HookEventPreServe:         true,
+	HookEventPreUnserve:       true,
+	HookEventPreRemove:        true,
+	HookEventPreUninstall:     true,
+	HookEventPreClusterCreate: true,
+	HookEventPreClusterRemove: true,
+}
+
+// IsPre returns true for the events sent before an
+// operation. Their hooks could abort the operation.
+func (h HookEvent) IsPre() bool {
+	return preHookEvents[h]
+}
+
+// HookPayload describes the lifecycle event passed to hooks.
+// Fields that don't apply to an event are left empty
+// (e.g. Env during uninstall or ServedPort during init).
+type HookPayload struct {
+	Event        HookEvent
+	CloudService CloudService
+	Config       *Config
+	Cluster      *Cluster
+	Env          *Env
+	ServedPort   EnvServedPort
+	PortBinding  string
+}
+
+type Hook interface {
+	Handle(HookPayload) error
+}
+
+// HookFunc lets ordinary functions be used as hooks
+type HookFunc func(HookPayload) error
+
+func (h HookFunc) Handle(payload HookPayload) error {
+	return h(payload)
+}
+
+// HookRunnerHook lets existing hook runners
+// be registered for lifecycle events
+type HookRunnerHook struct {
+	HookRunner HookRunner
+}
+
+func (h HookRunnerHook) Handle(payload HookPayload) error {
+	return h.HookRunner.Run(
+		payload.CloudService,
+		payload.Config,
+		payload.Cluster,
+		payload.Env,
+	)
+}
+
+// HookRegistry holds the hooks registered for each lifecycle
+// event. Nil and zero-value registries are valid and run no hooks.
+type HookRegistry struct {
+	hooks map[HookEvent][]Hook
+}
+
+func NewHookRegistry() *HookRegistry {
+	return &HookRegistry{
+		hooks: map[HookEvent][]Hook{},
+	}
+}
+
+// Register adds the hook for the passed event.
+// Hooks are run in registration order.
+func (h *HookRegistry) Register(event HookEvent, hook Hook) {
+	if h.hooks == nil {
+		h.hooks = map[HookEvent][]Hook{}
+	}
+
+	h.hooks[event] = append(h.hooks[event], hook)
+}
+
+// Run runs the hooks registered for the payload's event.
+// Pre-hooks stop at the first error, which must abort the
+// operation. Post-hooks are all run given that the operation
+// is already done and their errors are aggregated.
+func (h *HookRegistry) Run(payload HookPayload) error {
+	if h == nil {
+		return nil
+	}
+
+	if payload.Event.IsPre() {
+		return h.runPreHooks(payload)
+	}
+
+	return h.runPostHooks(payload)
+}
+
+func (h *HookRegistry) runPreHooks(payload HookPayload) error {
+	for _, hook := range h.hooks[payload.Event] {
+		err := hook.Handle(payload)
+
+		if err != nil {
+			return ErrPreHookFailed{
+				Event: payload.Event,
+				Err:   err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *HookRegistry) runPostHooks(payload HookPayload) error {
+	errs := []error{}
+
+	for _, hook := range h.hooks[payload.Event] {
+		err := hook.Handle(payload)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return ErrPostHooksFailed{
+			Event: payload.Event,
+			Errs:  errs,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHookRegistryRunPreHooks(t *testing.T) {
+	registry := NewHookRegistry()
+	hookErr := errors.New("hook_error")
+	calls := []string{}
+
+	registry.Register(HookEventPreRemove, HookFunc(func(payload HookPayload) error {
+		calls = append(calls, "first")
+		return hookErr
+	}))
+
+	registry.Register(HookEventPreRemove, HookFunc(func(payload HookPayload) error {
+		calls = append(calls, "second")
+		return nil
+	}))
+
+	err := registry.Run(HookPayload{
+		Event: HookEventPreRemove,
+	})
+
+	var preHookErr ErrPreHookFailed
+
+	if !errors.As(err, &preHookErr) || preHookErr.Event != HookEventPreRemove {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrPreHookFailed{Event: HookEventPreRemove},
+			err,
+		)
+	}
+
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("expected error to wrap the hook error, got '%+v'", err)
+	}
+
+	// Pre-hooks stop at the first error
+	if !reflect.DeepEqual(calls, []string{"first"}) {
+		t.Fatalf("expected only the first hook to be called, got '%+v'", calls)
+	}
+}
+
+func TestHookRegistryRunPostHooks(t *testing.T) {
+	registry := NewHookRegistry()
+	firstErr := errors.New("first_error")
+	secondErr := errors.New("second_error")
+	calls := []string{}
+
+	for _, hookErr := range []error{firstErr, nil, secondErr} {
+		hookErr := hookErr
+
+		registry.Register(HookEventPostRemove, HookFunc(func(payload HookPayload) error {
+			calls = append(calls, payload.Env.Name)
+			return hookErr
+		}))
+	}
+
+	err := registry.Run(HookPayload{
+		Event: HookEventPostRemove,
+		Env:   &Env{Name: "env"},
+	})
+
+	var postHooksErr ErrPostHooksFailed
+
+	if !errors.As(err, &postHooksErr) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrPostHooksFailed{},
+			err,
+		)
+	}
+
+	if !reflect.DeepEqual(postHooksErr.Errs, []error{firstErr, secondErr}) {
+		t.Fatalf(
+			"expected aggregated errors to equal '%+v', got '%+v'",
+			[]error{firstErr, secondErr},
+			postHooksErr.Errs,
+		)
+	}
+
+	// Post-hooks are all run
+	if len(calls) != 3 {
+		t.Fatalf("expected all hooks to be called, got '%+v'", calls)
+	}
+}
+
+func TestHookRegistryRunWithoutHooks(t *testing.T) {
+	var nilRegistry *HookRegistry
+
+	err := nilRegistry.Run(HookPayload{Event: HookEventPreInit})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = NewHookRegistry().Run(HookPayload{Event: HookEventPostInit})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestHookRegistryRegisterWithZeroValue(t *testing.T) {
+	registry := &HookRegistry{}
+	called := false
+
+	registry.Register(HookEventPostInit, HookFunc(func(payload HookPayload) error {
+		called = true
+		return nil
+	}))
+
+	err := registry.Run(HookPayload{Event: HookEventPostInit})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !called {
+		t.Fatalf("expected hook to be called")
+	}
+}
@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"sort"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const (
+	LabelKeyRegExp      = `^[a-z0-9]([a-z0-9._/-]*[a-z0-9])?$`
+	LabelKeyMaxLength   = 63
+	LabelValueRegExp    = `^([a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?)?$`
+	LabelValueMaxLength = 63
+	// Cloud providers limit the number of tags per resource
+	MaxLabelsPerResource = 32
+)
+
+// Labels are user-defined key/value metadata (e.g. owner,
+// team or cost center) propagated to cloud resource tags.
+type Labels map[string]string
+
+func CheckLabelKeyValidity(key string) error {
+	if len(key) > LabelKeyMaxLength ||
+		!govalidator.Matches(key, LabelKeyRegExp) {
+
+		return ErrInvalidLabelKey{
+			Key:          key,
+			KeyRegExp:    LabelKeyRegExp,
+			KeyMaxLength: LabelKeyMaxLength,
+		}
+	}
+
+	return nil
+}
+
+func CheckLabelValueValidity(key, value string) error {
+	if len(value) > LabelValueMaxLength ||
+		!govalidator.Matches(value, LabelValueRegExp) {
+
+		return ErrInvalidLabelValue{
+			Key:            key,
+			Value:          value,
+			ValueRegExp:    LabelValueRegExp,
+			ValueMaxLength: LabelValueMaxLength,
+		}
+	}
+
+	return nil
+}
+
+func CheckLabelsValidity(labels Labels) error {
+	for _, key := range labels.GetSortedKeys() {
+		err := CheckLabelKeyValidity(key)
+
+		if err != nil {
+			return err
+		}
+
+		err = CheckLabelValueValidity(key, labels[key])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(labels) > MaxLabelsPerResource {
+		return ErrTooManyLabels{
+			NbOfLabels:           len(labels),
+			MaxLabelsPerResource: MaxLabelsPerResource,
+		}
+	}
+
+	return nil
+}
+
+// Merge returns a copy of the labels updated
+// with the passed ones. Labels are not modified
+// to let callers validate the result first.
+func (l Labels) Merge(labels Labels) Labels {
+	mergedLabels := Labels{}
+
+	for key, value := range l {
+		mergedLabels[key] = value
+	}
+
+	for key, value := range labels {
+		mergedLabels[key] = value
+	}
+
+	return mergedLabels
+}
+
+// Without returns a copy of the labels without the passed keys
+func (l Labels) Without(keys []string) Labels {
+	remainingLabels := Labels{}
+
+	for key, value := range l {
+		if containsString(keys, key) {
+			continue
+		}
+
+		remainingLabels[key] = value
+	}
+
+	return remainingLabels
+}
+
+// Matches returns true if the labels contain
+// all the passed ones with the same values
+func (l Labels) Matches(labels Labels) bool {
+	for key, value := range labels {
+		labelValue, ok := l[key]
+
+		if !ok || labelValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l Labels) GetSortedKeys() []string {
+	keys := []string{}
+
+	for key := range l {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// SetLabels validates and sets the passed labels on the env
+func (e *Env) SetLabels(labels Labels) error {
+	mergedLabels := e.Labels.Merge(labels)
+	err := CheckLabelsValidity(mergedLabels)
+
+	if err != nil {
+		return err
+	}
+
+	e.Labels = mergedLabels
+
+	return nil
+}
+
+func (e *Env) UnsetLabels(keys []string) {
+	e.Labels = e.Labels.Without(keys)
+}
+
+// SetLabels validates and sets the passed labels on the cluster
+func (c *Cluster) SetLabels(labels Labels) error {
+	mergedLabels := c.Labels.Merge(labels)
+	err := CheckLabelsValidity(mergedLabels)
+
+	if err != nil {
+		return err
+	}
+
+	c.Labels = mergedLabels
+
+	return nil
+}
+
+func (c *Cluster) UnsetLabels(keys []string) {
+	c.Labels = c.Labels.Without(keys)
+}
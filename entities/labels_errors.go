@@ -0,0 +1,47 @@
+package entities
+
+type ErrInvalidLabelKey struct {
+	Key          string
+	KeyRegExp    string
+	KeyMaxLength int
+}
+
+func (ErrInvalidLabelKey) Error() string {
+	return "ErrInvalidLabelKey"
+}
+
+type ErrInvalidLabelValue struct {
+	Key            string
+	Value          string
+	ValueRegExp    string
+	ValueMaxLength int
+}
+
+func (ErrInvalidLabelValue) Error() string {
+	return "ErrInvalidLabelValue"
+}
+
+type ErrTooManyLabels struct {
+	NbOfLabels           int
+	MaxLabelsPerResource int
+}
+
+func (ErrTooManyLabels) Error() string {
+	return "ErrTooManyLabels"
+}
+
+type ErrLabelsRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrLabelsRemovingEnv) Error() string {
+	return "ErrLabelsRemovingEnv"
+}
+
+type ErrLabelsCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrLabelsCreatingEnv) Error() string {
+	return "ErrLabelsCreatingEnv"
+}
@@ -0,0 +1,175 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckLabelsValidity(t *testing.T) {
+	tooManyLabels := Labels{}
+	for labelIndex := 0; labelIndex <= MaxLabelsPerResource; labelIndex++ {
+		tooManyLabels[fmt.Sprintf("key%d", labelIndex)] = "value"
+	}
+
+	testCases := []struct {
+		test          string
+		labels        Labels
+		expectedError error
+	}{
+		{
+			test: "with valid labels",
+			labels: Labels{
+				"owner":          "john",
+				"eleven.sh/team": "api",
+				"cost-center":    "",
+				"ticket":         "ELEVEN-1234",
+			},
+			expectedError: nil,
+		},
+
+		{
+			test:   "with invalid key",
+			labels: Labels{"Owner": "john"},
+			expectedError: ErrInvalidLabelKey{
+				Key:          "Owner",
+				KeyRegExp:    LabelKeyRegExp,
+				KeyMaxLength: LabelKeyMaxLength,
+			},
+		},
+
+		{
+			test:   "with too long key",
+			labels: Labels{strings.Repeat("a", LabelKeyMaxLength+1): "value"},
+			expectedError: ErrInvalidLabelKey{
+				Key:          strings.Repeat("a", LabelKeyMaxLength+1),
+				KeyRegExp:    LabelKeyRegExp,
+				KeyMaxLength: LabelKeyMaxLength,
+			},
+		},
+
+		{
+			test:   "with invalid value",
+			labels: Labels{"owner": "john doe"},
+			expectedError: ErrInvalidLabelValue{
+				Key:            "owner",
+				Value:          "john doe",
+				ValueRegExp:    LabelValueRegExp,
+				ValueMaxLength: LabelValueMaxLength,
+			},
+		},
+
+		{
+			test:   "with too many labels",
+			labels: tooManyLabels,
+			expectedError: ErrTooManyLabels{
+				NbOfLabels:           MaxLabelsPerResource + 1,
+				MaxLabelsPerResource: MaxLabelsPerResource,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := CheckLabelsValidity(tc.labels)
+
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvSetAndUnsetLabels(t *testing.T) {
+	env := &Env{Labels: Labels{"owner": "john"}}
+
+	err := env.SetLabels(Labels{"team": "api", "owner": "john"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedLabels := Labels{"team": "api", "owner": "john"}
+
+	if !reflect.DeepEqual(expectedLabels, env.Labels) {
+		t.Fatalf(
+			"expected labels to equal '%+v', got '%+v'",
+			expectedLabels,
+			env.Labels,
+		)
+	}
+
+	err = env.SetLabels(Labels{"Invalid": "value"})
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+
+	if !reflect.DeepEqual(expectedLabels, env.Labels) {
+		t.Fatalf("expected labels to be unchanged on error, got '%+v'", env.Labels)
+	}
+
+	env.UnsetLabels([]string{"owner", "unknown"})
+
+	if !reflect.DeepEqual(Labels{"team": "api"}, env.Labels) {
+		t.Fatalf("expected only 'team' label to remain, got '%+v'", env.Labels)
+	}
+}
+
+func TestLabelsMatches(t *testing.T) {
+	labels := Labels{"team": "api", "owner": "john"}
+
+	testCases := []struct {
+		test            string
+		selector        Labels
+		expectedMatches bool
+	}{
+		{
+			test:            "with empty selector",
+			selector:        Labels{},
+			expectedMatches: true,
+		},
+
+		{
+			test:            "with matching selector",
+			selector:        Labels{"team": "api"},
+			expectedMatches: true,
+		},
+
+		{
+			test:            "with different value",
+			selector:        Labels{"team": "web"},
+			expectedMatches: false,
+		},
+
+		{
+			test:            "with missing key",
+			selector:        Labels{"ticket": "ELEVEN-1"},
+			expectedMatches: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			matches := labels.Matches(tc.selector)
+
+			if matches != tc.expectedMatches {
+				t.Fatalf(
+					"expected matches to equal %t, got %t",
+					tc.expectedMatches,
+					matches,
+				)
+			}
+		})
+	}
+}
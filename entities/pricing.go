@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// HoursPerMonth is the average number of hours in a month
+const HoursPerMonth = 730
+
+// PriceTable is the offline price table provided by cloud
+// services for the region of the current configuration.
+// Prices are not fetched in real time and are only estimates.
+type PriceTable struct {
+	Currency             string             `json:"currency"`
+	Region               string             `json:"region"`
+	InstanceHourlyPrices map[string]float64 `json:"instance_hourly_prices"`
+	// Envs storage is billed even when they are stopped
+	EnvStorageSizeGB      float64 `json:"env_storage_size_gb"`
+	StorageGBMonthlyPrice float64 `json:"storage_gb_monthly_price"`
+	PublicIPHourlyPrice   float64 `json:"public_ip_hourly_price"`
+	// ClusterHourlyPrice is the cost of the shared
+	// cluster infrastructure (e.g. network)
+	ClusterHourlyPrice float64 `json:"cluster_hourly_price"`
+}
+
+type CostEstimate struct {
+	Currency    string
+	HourlyCost  float64
+	MonthlyCost float64
+	// AccruedCost is estimated as if the
+	// current hourly cost applied since creation
+	AccruedCost float64
+	// Unknown is set when the offline price table doesn't
+	// contain the price of the instance type. Unknown
+	// estimates are zeroed and skipped in totals.
+	Unknown bool
+}
+
+type EnvCostEstimate struct {
+	EnvName string
+	CostEstimate
+}
+
+type ClusterCostEstimate struct {
+	ClusterName string
+	// Infrastructure is the cost of the cluster without its envs
+	Infrastructure CostEstimate
+	Envs           []EnvCostEstimate
+	// Total is the cost of the cluster including its envs
+	Total CostEstimate
+}
+
+func (p *PriceTable) GetInstanceHourlyPrice(instanceType string) (float64, error) {
+	price, ok := p.InstanceHourlyPrices[instanceType]
+
+	if !ok {
+		return 0, ErrUnknownInstanceTypePrice{
+			InstanceType: instanceType,
+			Region:       p.Region,
+		}
+	}
+
+	return price, nil
+}
+
+// EstimateInstanceTypeCost returns the projected cost
+// of an env using the passed instance type
+func (p *PriceTable) EstimateInstanceTypeCost(instanceType string) CostEstimate {
+	instanceHourlyPrice, err := p.GetInstanceHourlyPrice(instanceType)
+
+	if err != nil {
+		return p.newUnknownCostEstimate()
+	}
+
+	return p.newCostEstimate(
+		instanceHourlyPrice+p.getEnvFixedHourlyPrice(),
+		0,
+	)
+}
+
+func (p *PriceTable) EstimateEnvCost(env *Env, now time.Time) EnvCostEstimate {
+	hourlyCost := p.getEnvFixedHourlyPrice()
+
+	// Stopped instances are not billed
+	if env.Status != EnvStatusStopped {
+		instanceHourlyPrice, err := p.GetInstanceHourlyPrice(env.InstanceType)
+
+		if err != nil {
+			return EnvCostEstimate{
+				EnvName:      env.Name,
+				CostEstimate: p.newUnknownCostEstimate(),
+			}
+		}
+
+		hourlyCost += instanceHourlyPrice
+	}
+
+	return EnvCostEstimate{
+		EnvName: env.Name,
+		CostEstimate: p.newCostEstimate(
+			hourlyCost,
+			getHoursSince(env.CreatedAtTimestamp, now),
+		),
+	}
+}
+
+func (p *PriceTable) EstimateClusterCost(
+	cluster *Cluster,
+	now time.Time,
+) ClusterCostEstimate {
+
+	clusterEstimate := ClusterCostEstimate{
+		ClusterName: cluster.Name,
+		Infrastructure: p.newCostEstimate(
+			p.ClusterHourlyPrice,
+			getHoursSince(cluster.CreatedAtTimestamp, now),
+		),
+		Envs: []EnvCostEstimate{},
+	}
+
+	clusterEstimate.Total = clusterEstimate.Infrastructure
+
+	for _, env := range cluster.GetSortedEnvs() {
+		envEstimate := p.EstimateEnvCost(env, now)
+
+		clusterEstimate.Envs = append(clusterEstimate.Envs, envEstimate)
+
+		if envEstimate.Unknown {
+			continue
+		}
+
+		clusterEstimate.Total.HourlyCost += envEstimate.HourlyCost
+		clusterEstimate.Total.MonthlyCost += envEstimate.MonthlyCost
+		clusterEstimate.Total.AccruedCost += envEstimate.AccruedCost
+	}
+
+	return clusterEstimate
+}
+
+func (p *PriceTable) getEnvFixedHourlyPrice() float64 {
+	storageHourlyPrice := p.EnvStorageSizeGB * p.StorageGBMonthlyPrice / HoursPerMonth
+
+	return storageHourlyPrice + p.PublicIPHourlyPrice
+}
+
+func (p *PriceTable) newCostEstimate(hourlyCost, hours float64) CostEstimate {
+	return CostEstimate{
+		Currency:    p.Currency,
+		HourlyCost:  hourlyCost,
+		MonthlyCost: hourlyCost * HoursPerMonth,
+		AccruedCost: hourlyCost * hours,
+	}
+}
+
+func (p *PriceTable) newUnknownCostEstimate() CostEstimate {
+	return CostEstimate{
+		Currency: p.Currency,
+		Unknown:  true,
+	}
+}
+
+func getHoursSince(timestamp int64, now time.Time) float64 {
+	return math.Max(0, now.Sub(time.Unix(timestamp, 0)).Hours())
+}
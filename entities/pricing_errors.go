@@ -0,0 +1,10 @@
+package entities
+
+type ErrUnknownInstanceTypePrice struct {
+	InstanceType string
+	Region       string
+}
+
+func (ErrUnknownInstanceTypePrice) Error() string {
+	return "ErrUnknownInstanceTypePrice"
+}
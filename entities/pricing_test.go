@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func buildTestPriceTable() *PriceTable {
+	return &PriceTable{
+		Currency: "USD",
+		Region:   "eu-west-3",
+		InstanceHourlyPrices: map[string]float64{
+			"t2.medium": 0.05,
+		},
+		EnvStorageSizeGB:      73,
+		StorageGBMonthlyPrice: 0.1,
+		PublicIPHourlyPrice:   0.005,
+		ClusterHourlyPrice:    0.01,
+	}
+}
+
+func assertCostEqual(t *testing.T, name string, expected, actual float64) {
+	t.Helper()
+
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Fatalf("expected %s to equal %f, got %f", name, expected, actual)
+	}
+}
+
+func TestPriceTableEstimateInstanceTypeCost(t *testing.T) {
+	priceTable := buildTestPriceTable()
+
+	estimate := priceTable.EstimateInstanceTypeCost("t2.medium")
+
+	if estimate.Unknown {
+		t.Fatalf("expected estimate to be known")
+	}
+
+	// 0.05 + 73 * 0.1 / 730 + 0.005
+	expectedHourlyCost := 0.065
+
+	assertCostEqual(t, "hourly cost", expectedHourlyCost, estimate.HourlyCost)
+	assertCostEqual(t, "monthly cost", expectedHourlyCost*HoursPerMonth, estimate.MonthlyCost)
+	assertCostEqual(t, "accrued cost", 0, estimate.AccruedCost)
+
+	estimate = priceTable.EstimateInstanceTypeCost("unknown")
+
+	if !estimate.Unknown {
+		t.Fatalf("expected estimate to be unknown")
+	}
+
+	assertCostEqual(t, "unknown hourly cost", 0, estimate.HourlyCost)
+
+	_, err := priceTable.GetInstanceHourlyPrice("unknown")
+
+	if !errors.As(err, &ErrUnknownInstanceTypePrice{}) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrUnknownInstanceTypePrice{},
+			err,
+		)
+	}
+}
+
+func TestPriceTableEstimateClusterCost(t *testing.T) {
+	now := time.Unix(100000, 0)
+	tenHoursAgo := now.Add(-10 * time.Hour).Unix()
+
+	priceTable := buildTestPriceTable()
+
+	cluster := &Cluster{
+		Name:               "default",
+		CreatedAtTimestamp: tenHoursAgo,
+		Envs: map[string]*Env{
+			"running": {
+				Name:               "running",
+				InstanceType:       "t2.medium",
+				Status:             EnvStatusCreated,
+				CreatedAtTimestamp: tenHoursAgo,
+			},
+
+			"stopped": {
+				Name:               "stopped",
+				InstanceType:       "t2.medium",
+				Status:             EnvStatusStopped,
+				CreatedAtTimestamp: tenHoursAgo,
+			},
+
+			"unpriced": {
+				Name:               "unpriced",
+				InstanceType:       "unknown",
+				Status:             EnvStatusCreated,
+				CreatedAtTimestamp: tenHoursAgo,
+			},
+		},
+	}
+
+	estimate := priceTable.EstimateClusterCost(cluster, now)
+
+	if len(estimate.Envs) != 3 {
+		t.Fatalf("expected 3 env estimates, got %d", len(estimate.Envs))
+	}
+
+	if !estimate.Envs[2].Unknown {
+		t.Fatalf("expected unpriced env estimate to be unknown")
+	}
+
+	assertCostEqual(t, "running env hourly cost", 0.065, estimate.Envs[0].HourlyCost)
+	assertCostEqual(t, "running env accrued cost", 0.65, estimate.Envs[0].AccruedCost)
+	assertCostEqual(t, "stopped env hourly cost", 0.015, estimate.Envs[1].HourlyCost)
+	assertCostEqual(t, "cluster infrastructure hourly cost", 0.01, estimate.Infrastructure.HourlyCost)
+	assertCostEqual(t, "cluster total hourly cost", 0.09, estimate.Total.HourlyCost)
+	assertCostEqual(t, "cluster total accrued cost", 0.9, estimate.Total.AccruedCost)
+}
@@ -0,0 +1,60 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// updateCollaborators applies the passed collaborator
+// updates on the env named "envName" in the default cluster.
+// The instance's authorized keys are then updated accordingly.
+func updateCollaborators(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	envName string,
+	updateEnvCollaborators func(*entities.Env) error,
+) (*entities.Cluster, *entities.Env, error) {
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return nil, nil, entities.ErrAccessRemovingEnv{
+			EnvName: envName,
+		}
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return nil, nil, entities.ErrAccessCreatingEnv{
+			EnvName: envName,
+		}
+	}
+
+	err = updateEnvCollaborators(env)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = actions.UpdateEnvAuthorizedKeys(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	return cluster, env, err
+}
@@ -0,0 +1,132 @@
+package features
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// Executor is implemented by all the features
+type Executor[I any] interface {
+	Execute(I) error
+}
+
+type AuditOutput struct {
+	Content *AuditOutputContent
+	Stepper stepper.Stepper
+}
+
+type AuditOutputContent struct {
+	Entry *entities.AuditLogEntry
+	// RecordError is the error returned while recording
+	// the entry in the audit log. The feature is run in any case.
+	RecordError error
+}
+
+type AuditOutputHandler interface {
+	HandleOutput(AuditOutput) error
+}
+
+// AuditedFeature wraps a feature to record each of its
+// executions in the audit log of the Eleven config.
+type AuditedFeature[I any] struct {
+	featureName         string
+	feature             Executor[I]
+	stepper             stepper.Stepper
+	outputHandler       AuditOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+	actorResolver       entities.ActorResolver
+}
+
+func NewAuditedFeature[I any](
+	featureName string,
+	feature Executor[I],
+	stepper stepper.Stepper,
+	outputHandler AuditOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+	actorResolver entities.ActorResolver,
+) AuditedFeature[I] {
+
+	return AuditedFeature[I]{
+		featureName:         featureName,
+		feature:             feature,
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+		actorResolver:       actorResolver,
+	}
+}
+
+func (a AuditedFeature[I]) Execute(input I) error {
+	actor := entities.AuditLogUnknownActor
+
+	if a.actorResolver != nil {
+		// An unresolvable actor must not
+		// prevent the feature from running
+		if resolvedActor, err := a.actorResolver.ResolveActor(); err == nil {
+			actor = resolvedActor
+		}
+	}
+
+	entry := entities.NewAuditLogEntry(
+		a.featureName,
+		actor,
+		entities.BuildAuditLogEntryInputs(input),
+		time.Now(),
+	)
+
+	featureErr := a.feature.Execute(input)
+
+	entry.End(time.Now(), featureErr)
+
+	// A failure to record the entry must not make a successful
+	// execution look failed given that it can't be undone.
+	// It is reported in the output instead.
+	recordErr := a.recordEntry(entry)
+
+	err := a.outputHandler.HandleOutput(AuditOutput{
+		Stepper: a.stepper,
+		Content: &AuditOutputContent{
+			Entry:       entry,
+			RecordError: recordErr,
+		},
+	})
+
+	if featureErr != nil {
+		return featureErr
+	}
+
+	return err
+}
+
+func (a AuditedFeature[I]) recordEntry(entry *entities.AuditLogEntry) error {
+	cloudService, err := a.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return err
+	}
+
+	// The config is looked up again given that
+	// the feature may have modified it
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		a.stepper,
+	)
+
+	// Nothing to record in (e.g. after uninstall)
+	if errors.Is(err, entities.ErrElevenNotInstalled) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	elevenConfig.AppendAuditLogEntry(*entry)
+
+	return cloudService.SaveElevenConfig(
+		a.stepper,
+		elevenConfig,
+	)
+}
@@ -0,0 +1,102 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type AuditLogInput struct {
+	EnvName string
+	Actor   string
+	Since   time.Time
+	Until   time.Time
+	// ActorResolver identifies the person querying the
+	// audit log, not the actor used to filter entries
+	ActorResolver entities.ActorResolver
+}
+
+type AuditLogOutput struct {
+	Error   error
+	Content *AuditLogOutputContent
+	Stepper stepper.Stepper
+}
+
+type AuditLogOutputContent struct {
+	Entries []entities.AuditLogEntry
+}
+
+type AuditLogOutputHandler interface {
+	HandleOutput(AuditLogOutput) error
+}
+
+type AuditLogFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       AuditLogOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewAuditLogFeature(
+	stepper stepper.Stepper,
+	outputHandler AuditLogOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) AuditLogFeature {
+
+	return AuditLogFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (a AuditLogFeature) Execute(input AuditLogInput) error {
+	handleError := func(err error) error {
+		a.outputHandler.HandleOutput(AuditLogOutput{
+			Stepper: a.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	a.stepper.StartTemporaryStep("Querying the audit log")
+
+	cloudService, err := a.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		a.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionRead,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	entries := elevenConfig.QueryAuditLog(entities.AuditLogFilter{
+		EnvName: input.EnvName,
+		Actor:   input.Actor,
+		Since:   input.Since,
+		Until:   input.Until,
+	})
+
+	return a.outputHandler.HandleOutput(AuditLogOutput{
+		Stepper: a.stepper,
+		Content: &AuditLogOutputContent{
+			Entries: entries,
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package features
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type fakeExecutor struct {
+	err error
+}
+
+func (f fakeExecutor) Execute(struct{}) error {
+	return f.err
+}
+
+type fakeSaveCloudService struct {
+	fakeCloudService
+	saveErr error
+}
+
+func (f fakeSaveCloudService) SaveElevenConfig(
+	stepper.Stepper,
+	*entities.Config,
+) error {
+
+	return f.saveErr
+}
+
+type recordingAuditOutputHandler struct {
+	outputs *[]AuditOutput
+}
+
+func (r recordingAuditOutputHandler) HandleOutput(output AuditOutput) error {
+	*r.outputs = append(*r.outputs, output)
+	return nil
+}
+
+func TestAuditedFeatureReportsRecordError(t *testing.T) {
+	saveErr := errors.New("save error")
+	featureErr := errors.New("feature error")
+
+	testCases := []struct {
+		test                string
+		featureErr          error
+		saveErr             error
+		expectedError       error
+		expectedRecordError error
+	}{
+		{
+			test: "with recorded entry",
+		},
+
+		{
+			test:                "with record error",
+			saveErr:             saveErr,
+			expectedRecordError: saveErr,
+		},
+
+		{
+			test:                "with feature and record errors",
+			featureErr:          featureErr,
+			saveErr:             saveErr,
+			expectedError:       featureErr,
+			expectedRecordError: saveErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			outputs := []AuditOutput{}
+
+			auditedFeature := NewAuditedFeature[struct{}](
+				"test",
+				fakeExecutor{err: tc.featureErr},
+				fakeStepper{},
+				recordingAuditOutputHandler{outputs: &outputs},
+				fakeCloudServiceBuilder{
+					cloudService: fakeSaveCloudService{
+						fakeCloudService: fakeCloudService{
+							elevenConfig: entities.NewConfig(),
+						},
+						saveErr: tc.saveErr,
+					},
+				},
+				fakeActorResolver{actor: "alice"},
+			)
+
+			err := auditedFeature.Execute(struct{}{})
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error to equal '%+v', got '%+v'", tc.expectedError, err)
+			}
+
+			if len(outputs) != 1 {
+				t.Fatalf("expected one output, got '%d'", len(outputs))
+			}
+
+			content := outputs[0].Content
+
+			if !errors.Is(content.RecordError, tc.expectedRecordError) {
+				t.Fatalf(
+					"expected record error to equal '%+v', got '%+v'",
+					tc.expectedRecordError,
+					content.RecordError,
+				)
+			}
+
+			if content.Entry.Actor != "alice" {
+				t.Fatalf("expected actor to equal 'alice', got '%s'", content.Entry.Actor)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package features
+
+import (
+	"strings"
+
+	"github.com/eleven-sh/eleven/entities"
+)
+
+// checkPermission returns ErrPermissionDenied if the person running
+// the feature is not granted the passed permission. It must be called
+// with the config that the feature then updates so that a membership
+// change can't happen between the check and the update.
+func checkPermission(
+	elevenConfig *entities.Config,
+	actorResolver entities.ActorResolver,
+	permission entities.Permission,
+) error {
+
+	return checkNamespacePermission(
+		elevenConfig,
+		actorResolver,
+		permission,
+		"",
+	)
+}
+
+// checkClusterPermission is the equivalent of checkPermission for
+// features updating a cluster. The namespace is derived from the
+// cluster so that namespace members can't target other namespaces.
+func checkClusterPermission(
+	elevenConfig *entities.Config,
+	actorResolver entities.ActorResolver,
+	permission entities.Permission,
+	clusterName string,
+) error {
+
+	// Nothing to protect (e.g. before install)
+	if elevenConfig == nil {
+		return nil
+	}
+
+	return checkNamespacePermission(
+		elevenConfig,
+		actorResolver,
+		permission,
+		elevenConfig.GetClusterNamespaceName(clusterName),
+	)
+}
+
+// checkNamespacePermission is the equivalent of checkPermission for
+// features updating a namespace. An empty namespace name means that
+// the permission is checked for the whole installation.
+func checkNamespacePermission(
+	elevenConfig *entities.Config,
+	actorResolver entities.ActorResolver,
+	permission entities.Permission,
+	namespaceName string,
+) error {
+
+	// Nothing to protect (e.g. before install)
+	if elevenConfig == nil {
+		return nil
+	}
+
+	if len(namespaceName) > 0 {
+		_, err := elevenConfig.GetNamespace(namespaceName)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Installations that were never shared
+	// don't require the actor to be resolved
+	if !elevenConfig.IsShared() {
+		return nil
+	}
+
+	// The actor can't be trusted if it is unknown
+	if actorResolver == nil {
+		return entities.ErrPermissionDenied{
+			Actor:      entities.AuditLogUnknownActor,
+			Permission: permission,
+			Namespace:  namespaceName,
+		}
+	}
+
+	actor, err := actorResolver.ResolveActor()
+
+	if err != nil {
+		return err
+	}
+
+	if len(namespaceName) == 0 {
+		return elevenConfig.CheckPermission(actor, permission)
+	}
+
+	return elevenConfig.CheckNamespacePermission(
+		namespaceName,
+		actor,
+		permission,
+	)
+}
+
+// resolveOwner returns the owner of the envs created by the person
+// running the feature. The owner is resolved from the actor, like
+// for the permission checks, so that the per-owner quotas can't be
+// bypassed. The passed owner is only used when no resolver is set.
+func resolveOwner(
+	actorResolver entities.ActorResolver,
+	owner string,
+) (string, error) {
+
+	if actorResolver == nil {
+		return owner, nil
+	}
+
+	actor, err := actorResolver.ResolveActor()
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(owner) > 0 && !strings.EqualFold(owner, actor) {
+		return "", entities.ErrEnvOwnerMismatch{
+			Owner: owner,
+			Actor: actor,
+		}
+	}
+
+	return actor, nil
+}
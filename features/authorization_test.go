@@ -0,0 +1,144 @@
+package features
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+)
+
+type fakeActorResolver struct {
+	actor string
+}
+
+func (f fakeActorResolver) ResolveActor() (string, error) {
+	return f.actor, nil
+}
+
+func TestCheckPermissionWithNamespaceMembersOnly(t *testing.T) {
+	elevenConfig := entities.NewConfig()
+	namespace := entities.NewNamespace("team-a")
+	elevenConfig.SetNamespace(namespace)
+
+	err := namespace.SetMember(
+		entities.NewConfigMember("jane", entities.MemberRoleAdmin),
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	testCases := []struct {
+		test          string
+		actorResolver entities.ActorResolver
+	}{
+		{
+			test:          "with namespace admin",
+			actorResolver: fakeActorResolver{actor: "jane"},
+		},
+
+		{
+			test:          "with non-member",
+			actorResolver: fakeActorResolver{actor: "mallory"},
+		},
+
+		{
+			test:          "with unknown actor",
+			actorResolver: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := checkPermission(
+				elevenConfig,
+				tc.actorResolver,
+				entities.PermissionManageInstallation,
+			)
+
+			if !errors.As(err, &entities.ErrPermissionDenied{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					entities.ErrPermissionDenied{},
+					err,
+				)
+			}
+		})
+	}
+
+	err = checkNamespacePermission(
+		elevenConfig,
+		fakeActorResolver{actor: "jane"},
+		entities.PermissionManageInstallation,
+		"team-a",
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestResolveOwner(t *testing.T) {
+	testCases := []struct {
+		test          string
+		actorResolver entities.ActorResolver
+		owner         string
+		expectedOwner string
+		expectedError error
+	}{
+		{
+			test:          "without actor resolver",
+			owner:         "john",
+			expectedOwner: "john",
+		},
+
+		{
+			test:          "with actor resolver and no owner",
+			actorResolver: fakeActorResolver{actor: "jane"},
+			expectedOwner: "jane",
+		},
+
+		{
+			test:          "with matching owner",
+			actorResolver: fakeActorResolver{actor: "jane"},
+			owner:         "Jane",
+			expectedOwner: "jane",
+		},
+
+		{
+			test:          "with other owner",
+			actorResolver: fakeActorResolver{actor: "jane"},
+			owner:         "john",
+			expectedError: entities.ErrEnvOwnerMismatch{
+				Owner: "john",
+				Actor: "jane",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			owner, err := resolveOwner(tc.actorResolver, tc.owner)
+
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+
+			if owner != tc.expectedOwner {
+				t.Fatalf(
+					"expected owner to equal '%s', got '%s'",
+					tc.expectedOwner,
+					owner,
+				)
+			}
+		})
+	}
+}
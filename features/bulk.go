@@ -0,0 +1,63 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+)
+
+// selectBulkEnvTargets returns the envs of the
+// default cluster that match the passed selector
+func selectBulkEnvTargets(
+	elevenConfig *entities.Config,
+	selector entities.EnvSelector,
+) ([]actions.EnvTarget, error) {
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	envs, err := elevenConfig.SelectEnvs(
+		cluster.Name,
+		selector,
+		time.Now(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	envTargets := []actions.EnvTarget{}
+
+	for _, env := range envs {
+		envTargets = append(envTargets, actions.EnvTarget{
+			Cluster: cluster,
+			Env:     env,
+		})
+	}
+
+	return envTargets, nil
+}
+
+func checkBulkEnvResults(results []actions.EnvOperationResult) error {
+	nbOfFailedEnvs := 0
+
+	for _, result := range results {
+		if result.Error != nil {
+			nbOfFailedEnvs++
+		}
+	}
+
+	if nbOfFailedEnvs > 0 {
+		return entities.ErrBulkEnvOperationFailed{
+			NbOfFailedEnvs: nbOfFailedEnvs,
+			NbOfEnvs:       len(results),
+		}
+	}
+
+	return nil
+}
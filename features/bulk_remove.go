@@ -0,0 +1,185 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type BulkRemoveInput struct {
+	Selector       entities.EnvSelector
+	MaxConcurrency int
+	PreRemoveHook  entities.HookRunner
+	ForceRemove    bool
+	ConfirmRemove  func([]*entities.Env) (bool, error)
+	// Hooks run concurrently for the removed envs
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
+}
+
+type BulkRemoveOutput struct {
+	Error   error
+	Content *BulkRemoveOutputContent
+	Stepper stepper.Stepper
+}
+
+type BulkRemoveOutputContent struct {
+	Results []actions.EnvOperationResult
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The envs are removed in any case.
+	PostHooksErrors []error
+}
+
+type BulkRemoveOutputHandler interface {
+	HandleOutput(BulkRemoveOutput) error
+}
+
+type BulkRemoveFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       BulkRemoveOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewBulkRemoveFeature(
+	stepper stepper.Stepper,
+	outputHandler BulkRemoveOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) BulkRemoveFeature {
+
+	return BulkRemoveFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (b BulkRemoveFeature) Execute(input BulkRemoveInput) error {
+	results := []actions.EnvOperationResult{}
+
+	handleError := func(err error) error {
+		b.outputHandler.HandleOutput(BulkRemoveOutput{
+			Stepper: b.stepper,
+			Error:   err,
+			Content: &BulkRemoveOutputContent{
+				Results: results,
+			},
+		})
+
+		return err
+	}
+
+	step := "Removing the selected sandboxes"
+	b.stepper.StartTemporaryStep(step)
+
+	err := entities.CheckEnvSelectorValidity(input.Selector)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := b.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		b.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	envTargets, err := selectBulkEnvTargets(
+		elevenConfig,
+		input.Selector,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if len(envTargets) > 0 && !input.ForceRemove && input.ConfirmRemove != nil {
+		b.stepper.StopCurrentStep()
+
+		envs := []*entities.Env{}
+		for _, envTarget := range envTargets {
+			envs = append(envs, envTarget.Env)
+		}
+
+		confirmed, err := input.ConfirmRemove(envs)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			return nil
+		}
+
+		b.stepper.StartTemporaryStep(step)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	// Config writes are not batched to keep track
+	// of the removals that could fail midway
+	results, err = actions.RunEnvOperationsInParallel(
+		b.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency: input.MaxConcurrency,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			return removeEnvWithHooks(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.PreRemoveHook,
+				input.Hooks,
+				postHooksErrs,
+			)
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkBulkEnvResults(results)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return b.outputHandler.HandleOutput(BulkRemoveOutput{
+		Stepper: b.stepper,
+		Content: &BulkRemoveOutputContent{
+			Results:         results,
+			PostHooksErrors: postHooksErrs.get(),
+		},
+	})
+}
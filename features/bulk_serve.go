@@ -0,0 +1,186 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// BulkServeInput has no port binding given
+// that a domain could only be bound to one env
+type BulkServeInput struct {
+	Selector       entities.EnvSelector
+	MaxConcurrency int
+	ReservedPorts  []string
+	Port           string
+	// Hooks run concurrently for the served envs
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
+}
+
+type BulkServeOutput struct {
+	Error   error
+	Content *BulkServeOutputContent
+	Stepper stepper.Stepper
+}
+
+type BulkServeOutputContent struct {
+	Port    string
+	Results []actions.EnvOperationResult
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The ports are served in any case.
+	PostHooksErrors []error
+}
+
+type BulkServeOutputHandler interface {
+	HandleOutput(BulkServeOutput) error
+}
+
+type BulkServeFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       BulkServeOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewBulkServeFeature(
+	stepper stepper.Stepper,
+	outputHandler BulkServeOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) BulkServeFeature {
+
+	return BulkServeFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (b BulkServeFeature) Execute(input BulkServeInput) error {
+	results := []actions.EnvOperationResult{}
+
+	handleError := func(err error) error {
+		b.outputHandler.HandleOutput(BulkServeOutput{
+			Stepper: b.stepper,
+			Error:   err,
+			Content: &BulkServeOutputContent{
+				Port:    input.Port,
+				Results: results,
+			},
+		})
+
+		return err
+	}
+
+	b.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Serving port \"%s\" in the selected sandboxes",
+			input.Port,
+		),
+	)
+
+	err := entities.CheckEnvSelectorValidity(input.Selector)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = entities.CheckPortValidity(
+		input.Port,
+		input.ReservedPorts,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := b.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		b.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	envTargets, err := selectBulkEnvTargets(
+		elevenConfig,
+		input.Selector,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	results, err = actions.RunEnvOperationsInParallel(
+		b.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency:    input.MaxConcurrency,
+			BatchConfigWrites: true,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			_, err := servePortWithHooks(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.Port,
+				input.Port,
+				nil,
+				input.Hooks,
+				postHooksErrs,
+			)
+
+			return err
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkBulkEnvResults(results)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return b.outputHandler.HandleOutput(BulkServeOutput{
+		Stepper: b.stepper,
+		Content: &BulkServeOutputContent{
+			Port:            input.Port,
+			Results:         results,
+			PostHooksErrors: postHooksErrs.get(),
+		},
+	})
+}
@@ -0,0 +1,180 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type BulkUnserveInput struct {
+	Selector       entities.EnvSelector
+	MaxConcurrency int
+	ReservedPorts  []string
+	Port           string
+	// Hooks run concurrently for the unserved envs
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
+}
+
+type BulkUnserveOutput struct {
+	Error   error
+	Content *BulkUnserveOutputContent
+	Stepper stepper.Stepper
+}
+
+type BulkUnserveOutputContent struct {
+	Port    string
+	Results []actions.EnvOperationResult
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The ports are unserved in any case.
+	PostHooksErrors []error
+}
+
+type BulkUnserveOutputHandler interface {
+	HandleOutput(BulkUnserveOutput) error
+}
+
+type BulkUnserveFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       BulkUnserveOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewBulkUnserveFeature(
+	stepper stepper.Stepper,
+	outputHandler BulkUnserveOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) BulkUnserveFeature {
+
+	return BulkUnserveFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (b BulkUnserveFeature) Execute(input BulkUnserveInput) error {
+	results := []actions.EnvOperationResult{}
+
+	handleError := func(err error) error {
+		b.outputHandler.HandleOutput(BulkUnserveOutput{
+			Stepper: b.stepper,
+			Error:   err,
+			Content: &BulkUnserveOutputContent{
+				Port:    input.Port,
+				Results: results,
+			},
+		})
+
+		return err
+	}
+
+	b.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Unserving port \"%s\" in the selected sandboxes",
+			input.Port,
+		),
+	)
+
+	err := entities.CheckEnvSelectorValidity(input.Selector)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = entities.CheckPortValidity(
+		input.Port,
+		input.ReservedPorts,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := b.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		b.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	envTargets, err := selectBulkEnvTargets(
+		elevenConfig,
+		input.Selector,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	results, err = actions.RunEnvOperationsInParallel(
+		b.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency:    input.MaxConcurrency,
+			BatchConfigWrites: true,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			return unservePortWithHooks(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.Port,
+				input.Hooks,
+				postHooksErrs,
+			)
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkBulkEnvResults(results)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return b.outputHandler.HandleOutput(BulkUnserveOutput{
+		Stepper: b.stepper,
+		Content: &BulkUnserveOutputContent{
+			Port:            input.Port,
+			Results:         results,
+			PostHooksErrors: postHooksErrs.get(),
+		},
+	})
+}
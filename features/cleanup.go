@@ -0,0 +1,183 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// DefaultCleanupMaxAge is the age after which envs and clusters
+// in a transitional status are considered as stale by default.
+const DefaultCleanupMaxAge = entities.ConfigStuckStatusThreshold
+
+type CleanupInput struct {
+	// MaxAge defaults to DefaultCleanupMaxAge when not set
+	MaxAge         time.Duration
+	PreRemoveHook  entities.HookRunner
+	ForceCleanup   bool
+	ConfirmCleanup func(entities.StaleResources) (bool, error)
+	Hooks          *entities.HookRegistry
+	ActorResolver  entities.ActorResolver
+}
+
+type CleanupOutput struct {
+	Error   error
+	Content *CleanupOutputContent
+	Stepper stepper.Stepper
+}
+
+type CleanupOutputContent struct {
+	NothingToCleanup bool
+	RemovedResources entities.StaleResources
+	// PostHooksErrors lists the errors returned by post-hooks.
+	// The stale resources are removed in any case.
+	PostHooksErrors []error
+}
+
+type CleanupOutputHandler interface {
+	HandleOutput(CleanupOutput) error
+}
+
+type CleanupFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       CleanupOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewCleanupFeature(
+	stepper stepper.Stepper,
+	outputHandler CleanupOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) CleanupFeature {
+
+	return CleanupFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (c CleanupFeature) Execute(input CleanupInput) error {
+	handleError := func(err error) error {
+		c.outputHandler.HandleOutput(CleanupOutput{
+			Stepper: c.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	maxAge := input.MaxAge
+
+	if maxAge <= 0 {
+		maxAge = DefaultCleanupMaxAge
+	}
+
+	c.stepper.StartTemporaryStep("Looking for stale sandboxes")
+
+	cloudService, err := c.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		c.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	staleResources := elevenConfig.FindStaleResources(
+		maxAge,
+		time.Now(),
+	)
+
+	if staleResources.IsEmpty() {
+		return c.outputHandler.HandleOutput(CleanupOutput{
+			Stepper: c.stepper,
+			Content: &CleanupOutputContent{
+				NothingToCleanup: true,
+				RemovedResources: staleResources,
+			},
+		})
+	}
+
+	if !input.ForceCleanup && input.ConfirmCleanup != nil {
+		c.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmCleanup(staleResources)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			return nil
+		}
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	for _, staleEnv := range staleResources.Envs {
+		c.stepper.StartTemporaryStep(
+			fmt.Sprintf("Removing the sandbox \"%s\"", staleEnv.Env.Name),
+		)
+
+		err = removeEnvWithHooks(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			staleEnv.Cluster,
+			staleEnv.Env,
+			input.PreRemoveHook,
+			input.Hooks,
+			postHooksErrs,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	for _, staleCluster := range staleResources.Clusters {
+		c.stepper.StartTemporaryStep(
+			fmt.Sprintf("Removing the cluster \"%s\"", staleCluster.Name),
+		)
+
+		err = removeClusterWithHooks(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			staleCluster,
+			input.Hooks,
+			postHooksErrs,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	return c.outputHandler.HandleOutput(CleanupOutput{
+		Stepper: c.stepper,
+		Content: &CleanupOutputContent{
+			NothingToCleanup: false,
+			RemovedResources: staleResources,
+			PostHooksErrors:  postHooksErrs.get(),
+		},
+	})
+}
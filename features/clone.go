@@ -0,0 +1,322 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type CloneInput struct {
+	SourceEnvName        string
+	EnvName              string
+	LocalSSHCfgDupHostCt int
+	// WithDiskSnapshot creates the new env
+	// from a snapshot of the source env's disk
+	WithDiskSnapshot bool
+	// WithServedPorts serves the ports bound to ports
+	// in the source env. Domains are never copied.
+	WithServedPorts bool
+	// Owner is the GitHub username of the user that clones
+	// the env. It is resolved using the actor resolver when
+	// set and must match the resolved actor if passed.
+	Owner string
+	// GitHubDeployKeysCreator is only called if the
+	// source env uses deploy keys. Keys are never shared.
+	GitHubDeployKeysCreator entities.GitHubDeployKeysCreator
+	Hooks                   *entities.HookRegistry
+	ActorResolver           entities.ActorResolver
+}
+
+type CloneOutput struct {
+	Error   error
+	Content *CloneOutputContent
+	Stepper stepper.Stepper
+}
+
+type CloneOutputContent struct {
+	CloudService    entities.CloudService
+	ElevenConfig    *entities.Config
+	Cluster         *entities.Cluster
+	SourceEnv       *entities.Env
+	Env             *entities.Env
+	SetEnvAsCreated func() error
+	Runtimes        entities.EnvRuntimes
+	SecretsFiles    []entities.EnvSecretsFile
+	// PostHooksErrors lists the errors returned by post-hooks.
+	// It is updated by SetEnvAsCreated.
+	PostHooksErrors []error
+}
+
+type CloneOutputHandler interface {
+	HandleOutput(CloneOutput) error
+}
+
+type CloneFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       CloneOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewCloneFeature(
+	stepper stepper.Stepper,
+	outputHandler CloneOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) CloneFeature {
+
+	return CloneFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (c CloneFeature) Execute(input CloneInput) error {
+	handleError := func(err error) error {
+		c.outputHandler.HandleOutput(CloneOutput{
+			Stepper: c.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	step := fmt.Sprintf(
+		"Cloning the sandbox \"%s\" into \"%s\"",
+		input.SourceEnvName,
+		envName,
+	)
+
+	c.stepper.StartTemporaryStep(step)
+
+	err := entities.CheckEnvNameValidity(envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := c.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		c.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	owner, err := resolveOwner(input.ActorResolver, input.Owner)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	sourceEnv, err := elevenConfig.GetEnv(
+		cluster.Name,
+		input.SourceEnvName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if sourceEnv.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrCloneRemovingEnv{
+			EnvName: sourceEnv.Name,
+		})
+	}
+
+	if sourceEnv.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrCloneCreatingEnv{
+			EnvName: sourceEnv.Name,
+		})
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil && !errors.As(err, &entities.ErrEnvNotExists{}) {
+		return handleError(err)
+	}
+
+	// Envs still in creating state
+	// after error could be cloned again
+	if env != nil && env.Status != entities.EnvStatusCreating {
+		return handleError(entities.ErrEnvAlreadyExists{
+			ClusterName: cluster.Name,
+			EnvName:     env.Name,
+		})
+	}
+
+	if env == nil {
+		err = actions.CheckQuotas(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			entities.QuotasCheckRequest{
+				ClusterName:  cluster.Name,
+				Owner:        owner,
+				InstanceType: sourceEnv.InstanceType,
+			},
+			time.Now(),
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		env = entities.NewEnvFromSource(
+			envName,
+			input.LocalSSHCfgDupHostCt,
+			sourceEnv,
+		)
+
+		env.Owner = owner
+	}
+
+	if input.WithDiskSnapshot {
+		err = actions.CreateEnvFromTemporarySnapshot(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			sourceEnv,
+			cluster,
+			env,
+			"clone-"+env.Name,
+		)
+	} else {
+		err = actions.CreateEnv(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+	}
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.UsesDeployKeys() && input.GitHubDeployKeysCreator != nil {
+		err = actions.CreateDeployKeys(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+			input.GitHubDeployKeysCreator,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	servedPorts := entities.EnvServedPorts{}
+
+	if input.WithServedPorts {
+		servedPorts = sourceEnv.GetPortBoundServedPorts()
+	}
+
+	// Current step is the last ended infrastructure step.
+	// Better UX if we reset to main step here given that
+	// the next steps (in GRPC agent) may take some time to start.
+	c.stepper.StartTemporaryStep(step)
+
+	postHooksErrs := newPostHooksErrors()
+
+	outputContent := &CloneOutputContent{
+		CloudService: cloudService,
+		ElevenConfig: elevenConfig,
+		Cluster:      cluster,
+		SourceEnv:    sourceEnv,
+		Env:          env,
+		Runtimes:     env.Runtimes,
+		SecretsFiles: env.BuildSecretsFiles(),
+	}
+
+	outputContent.SetEnvAsCreated = func() error {
+		env.SetStatus(entities.EnvStatusCreated)
+
+		err := actions.UpdateEnvInConfig(
+			c.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		postHooksErrs.run(input.Hooks, entities.HookPayload{
+			Event:        entities.HookEventEnvCreated,
+			CloudService: cloudService,
+			Config:       elevenConfig,
+			Cluster:      cluster,
+			Env:          env,
+		})
+
+		// Ports could only be served
+		// once the env is created
+		for _, servedPort := range servedPorts.GetSortedPorts() {
+			for _, binding := range servedPorts[servedPort] {
+				_, err := servePortWithHooks(
+					c.stepper,
+					cloudService,
+					elevenConfig,
+					cluster,
+					env,
+					string(servedPort),
+					binding.Value,
+					nil, // Only bindings to ports are served
+					input.Hooks,
+					postHooksErrs,
+				)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		outputContent.PostHooksErrors = postHooksErrs.get()
+
+		return nil
+	}
+
+	return c.outputHandler.HandleOutput(CloneOutput{
+		Stepper: c.stepper,
+		Content: outputContent,
+	})
+}
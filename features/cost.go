@@ -0,0 +1,121 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type CostInput struct {
+	ActorResolver entities.ActorResolver
+}
+
+type CostOutput struct {
+	Error   error
+	Content *CostOutputContent
+	Stepper stepper.Stepper
+}
+
+type CostOutputContent struct {
+	PriceTable *entities.PriceTable
+	Clusters   []entities.ClusterCostEstimate
+	// Total is the cost of all the clusters
+	Total entities.CostEstimate
+}
+
+type CostOutputHandler interface {
+	HandleOutput(CostOutput) error
+}
+
+type CostFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       CostOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewCostFeature(
+	stepper stepper.Stepper,
+	outputHandler CostOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) CostFeature {
+
+	return CostFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (c CostFeature) Execute(input CostInput) error {
+	handleError := func(err error) error {
+		c.outputHandler.HandleOutput(CostOutput{
+			Stepper: c.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	c.stepper.StartTemporaryStep("Estimating the cost of your sandboxes")
+
+	cloudService, err := c.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		c.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// The costs of all the clusters are estimated
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionRead,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	priceTable, err := cloudService.LookupPriceTable(
+		c.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	now := time.Now()
+	content := &CostOutputContent{
+		PriceTable: priceTable,
+		Clusters:   []entities.ClusterCostEstimate{},
+		Total: entities.CostEstimate{
+			Currency: priceTable.Currency,
+		},
+	}
+
+	for _, cluster := range elevenConfig.GetSortedClusters() {
+		clusterEstimate := priceTable.EstimateClusterCost(
+			cluster,
+			now,
+		)
+
+		content.Clusters = append(content.Clusters, clusterEstimate)
+
+		content.Total.HourlyCost += clusterEstimate.Total.HourlyCost
+		content.Total.MonthlyCost += clusterEstimate.Total.MonthlyCost
+		content.Total.AccruedCost += clusterEstimate.Total.AccruedCost
+	}
+
+	return c.outputHandler.HandleOutput(CostOutput{
+		Stepper: c.stepper,
+		Content: content,
+	})
+}
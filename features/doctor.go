@@ -0,0 +1,145 @@
+package features
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/eleven-sh/eleven/encryption"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type DoctorInput struct{}
+
+type DoctorOutput struct {
+	Error   error
+	Content *DoctorOutputContent
+	Stepper stepper.Stepper
+}
+
+type DoctorOutputContent struct {
+	// ConnectivityError is set when the cloud
+	// service could not be reached
+	ConnectivityError error
+	// ConfigError is set when the config could not be
+	// decoded (e.g. malformed JSON, newer schema version)
+	ConfigError error
+	// EncryptionError is set when
+	// the config could not be decrypted
+	EncryptionError error
+	ElevenInstalled bool
+	ConfigIssues    []entities.ConfigIssue
+}
+
+type DoctorOutputHandler interface {
+	HandleOutput(DoctorOutput) error
+}
+
+type DoctorFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       DoctorOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewDoctorFeature(
+	stepper stepper.Stepper,
+	outputHandler DoctorOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) DoctorFeature {
+
+	return DoctorFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (d DoctorFeature) Execute(input DoctorInput) error {
+	handleReport := func(content *DoctorOutputContent) error {
+		return d.outputHandler.HandleOutput(DoctorOutput{
+			Stepper: d.stepper,
+			Content: content,
+		})
+	}
+
+	d.stepper.StartTemporaryStep("Checking the cloud service connectivity")
+
+	cloudService, err := d.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleReport(&DoctorOutputContent{
+			ConnectivityError: err,
+			ConfigIssues:      []entities.ConfigIssue{},
+		})
+	}
+
+	connectivityChecker, ok := entities.LookupCloudServiceConnectivityChecker(
+		cloudService,
+	)
+
+	if ok {
+		err = connectivityChecker.CheckConnectivity(d.stepper)
+
+		if err != nil {
+			return handleReport(&DoctorOutputContent{
+				ConnectivityError: err,
+				ConfigIssues:      []entities.ConfigIssue{},
+			})
+		}
+	}
+
+	d.stepper.StartTemporaryStep("Validating the Eleven config")
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		d.stepper,
+	)
+
+	if errors.Is(err, entities.ErrElevenNotInstalled) {
+		return handleReport(&DoctorOutputContent{
+			ElevenInstalled: false,
+			ConfigIssues:    []entities.ConfigIssue{},
+		})
+	}
+
+	if err != nil {
+		return handleReport(classifyDoctorLookupError(err))
+	}
+
+	return handleReport(&DoctorOutputContent{
+		ElevenInstalled: true,
+		ConfigIssues:    elevenConfig.Validate(),
+	})
+}
+
+// classifyDoctorLookupError reports the errors returned while
+// looking up the config as config or encryption problems.
+// Other errors are considered as connectivity errors.
+func classifyDoctorLookupError(err error) *DoctorOutputContent {
+	content := &DoctorOutputContent{
+		ConfigIssues: []entities.ConfigIssue{},
+	}
+
+	var errDecryptionFailed encryption.ErrConfigDecryptionFailed
+	var errMigrationFailed entities.ErrConfigMigrationFailed
+	var errSchemaVersionTooNew entities.ErrConfigSchemaVersionTooNew
+	var errSyntax *json.SyntaxError
+	var errUnmarshalType *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &errDecryptionFailed):
+		content.ElevenInstalled = true
+		content.EncryptionError = err
+	case errors.As(err, &errMigrationFailed),
+		errors.As(err, &errSchemaVersionTooNew),
+		errors.As(err, &errSyntax),
+		errors.As(err, &errUnmarshalType),
+		errors.Is(err, entities.ErrConfigTrailingData):
+
+		content.ElevenInstalled = true
+		content.ConfigError = err
+	default:
+		content.ConnectivityError = err
+	}
+
+	return content
+}
@@ -0,0 +1,133 @@
+package features
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/eleven-sh/eleven/encryption"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type fakeLookupErrorCloudService struct {
+	entities.CloudService
+	lookupErr error
+}
+
+func (f fakeLookupErrorCloudService) LookupElevenConfig(
+	stepper.Stepper,
+) (*entities.Config, error) {
+
+	return nil, f.lookupErr
+}
+
+type recordingDoctorOutputHandler struct {
+	outputs *[]DoctorOutput
+}
+
+func (r recordingDoctorOutputHandler) HandleOutput(output DoctorOutput) error {
+	*r.outputs = append(*r.outputs, output)
+	return nil
+}
+
+func TestDoctorClassifiesLookupErrors(t *testing.T) {
+	malformedJSONErr := json.Unmarshal([]byte(`{"schema_version":`), &entities.Config{})
+	invalidTypeErr := json.Unmarshal([]byte(`"config"`), &map[string]interface{}{})
+
+	testCases := []struct {
+		test                      string
+		lookupErr                 error
+		expectedConnectivityError bool
+		expectedConfigError       bool
+		expectedEncryptionError   bool
+	}{
+		{
+			test:                      "with connectivity error",
+			lookupErr:                 errors.New("connection refused"),
+			expectedConnectivityError: true,
+		},
+
+		{
+			test:                "with malformed JSON",
+			lookupErr:           malformedJSONErr,
+			expectedConfigError: true,
+		},
+
+		{
+			test:                "with invalid JSON type",
+			lookupErr:           invalidTypeErr,
+			expectedConfigError: true,
+		},
+
+		{
+			test:                "with trailing data",
+			lookupErr:           entities.ErrConfigTrailingData,
+			expectedConfigError: true,
+		},
+
+		{
+			test: "with schema version too new",
+			lookupErr: entities.ErrConfigSchemaVersionTooNew{
+				SchemaVersion:          entities.ConfigSchemaVersion + 1,
+				SupportedSchemaVersion: entities.ConfigSchemaVersion,
+			},
+			expectedConfigError: true,
+		},
+
+		{
+			test: "with migration failure",
+			lookupErr: entities.ErrConfigMigrationFailed{
+				ToSchemaVersion: 2,
+				ReturnedError:   errors.New("migration error"),
+			},
+			expectedConfigError: true,
+		},
+
+		{
+			test: "with decryption failure",
+			lookupErr: encryption.ErrConfigDecryptionFailed{
+				ReturnedError: encryption.ErrInvalidEncryptedValue,
+			},
+			expectedEncryptionError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			outputs := []DoctorOutput{}
+
+			err := NewDoctorFeature(
+				fakeStepper{},
+				recordingDoctorOutputHandler{outputs: &outputs},
+				fakeCloudServiceBuilder{
+					cloudService: fakeLookupErrorCloudService{
+						lookupErr: tc.lookupErr,
+					},
+				},
+			).Execute(DoctorInput{})
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if len(outputs) != 1 || outputs[0].Content == nil {
+				t.Fatalf("expected one report, got '%+v'", outputs)
+			}
+
+			content := outputs[0].Content
+
+			if (content.ConnectivityError != nil) != tc.expectedConnectivityError {
+				t.Fatalf("expected connectivity error to be set: '%v', got '%+v'", tc.expectedConnectivityError, content.ConnectivityError)
+			}
+
+			if (content.ConfigError != nil) != tc.expectedConfigError {
+				t.Fatalf("expected config error to be set: '%v', got '%+v'", tc.expectedConfigError, content.ConfigError)
+			}
+
+			if (content.EncryptionError != nil) != tc.expectedEncryptionError {
+				t.Fatalf("expected encryption error to be set: '%v', got '%+v'", tc.expectedEncryptionError, content.EncryptionError)
+			}
+		})
+	}
+}
@@ -3,12 +3,14 @@ package features
 import (
 	"fmt"
 
+	"github.com/eleven-sh/eleven/actions"
 	"github.com/eleven-sh/eleven/entities"
 	"github.com/eleven-sh/eleven/stepper"
 )
 
 type EditInput struct {
-	EnvName string
+	EnvName       string
+	ActorResolver entities.ActorResolver
 }
 
 type EditOutput struct {
@@ -76,6 +78,18 @@ func (e EditFeature) Execute(input EditInput) error {
 	}
 
 	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	cluster, err := elevenConfig.GetCluster(clusterName)
 
 	if err != nil {
@@ -100,6 +114,24 @@ func (e EditFeature) Execute(input EditInput) error {
 		})
 	}
 
+	if env.Status == entities.EnvStatusStopped {
+		e.stepper.StartTemporaryStep(
+			fmt.Sprintf("Starting the sandbox \"%s\"", envName),
+		)
+
+		err = actions.StartEnv(
+			e.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
 	return e.outputHandler.HandleOutput(EditOutput{
 		Stepper: e.stepper,
 		Content: &EditOutputContent{
@@ -0,0 +1,211 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// ExpirySweepInput has no confirmation
+// callback given that sweeps run from cron jobs
+type ExpirySweepInput struct {
+	Action         string
+	GracePeriod    time.Duration
+	PreRemoveHook  entities.HookRunner
+	DryRun         bool
+	MaxConcurrency int
+	// Hooks run concurrently for the removed envs
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
+}
+
+type ExpirySweepOutput struct {
+	Error   error
+	Content *ExpirySweepOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExpirySweepOutputContent struct {
+	Action      entities.EnvExpiryAction
+	DryRun      bool
+	ExpiredEnvs []*entities.Env
+	// Results is empty during dry runs
+	Results []actions.EnvOperationResult
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The envs are removed in any case.
+	PostHooksErrors []error
+}
+
+type ExpirySweepOutputHandler interface {
+	HandleOutput(ExpirySweepOutput) error
+}
+
+type ExpirySweepFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExpirySweepOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExpirySweepFeature(
+	stepper stepper.Stepper,
+	outputHandler ExpirySweepOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExpirySweepFeature {
+
+	return ExpirySweepFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExpirySweepFeature) Execute(input ExpirySweepInput) error {
+	content := &ExpirySweepOutputContent{
+		DryRun:      input.DryRun,
+		ExpiredEnvs: []*entities.Env{},
+		Results:     []actions.EnvOperationResult{},
+	}
+
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExpirySweepOutput{
+			Stepper: e.stepper,
+			Error:   err,
+			Content: content,
+		})
+
+		return err
+	}
+
+	e.stepper.StartTemporaryStep("Looking for expired sandboxes")
+
+	action, err := entities.ParseEnvExpiryAction(input.Action)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	content.Action = action
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	expiredEnvs, err := elevenConfig.FindExpiredEnvs(
+		cluster.Name,
+		action,
+		input.GracePeriod,
+		time.Now(),
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	content.ExpiredEnvs = expiredEnvs
+
+	if input.DryRun || len(expiredEnvs) == 0 {
+		return e.outputHandler.HandleOutput(ExpirySweepOutput{
+			Stepper: e.stepper,
+			Content: content,
+		})
+	}
+
+	envTargets := []actions.EnvTarget{}
+
+	for _, env := range expiredEnvs {
+		envTargets = append(envTargets, actions.EnvTarget{
+			Cluster: cluster,
+			Env:     env,
+		})
+	}
+
+	e.stepper.StartTemporaryStep("Sweeping expired sandboxes")
+
+	postHooksErrs := newPostHooksErrors()
+
+	results, err := actions.RunEnvOperationsInParallel(
+		e.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency: input.MaxConcurrency,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			if action == entities.EnvExpiryActionStop {
+				return actions.StopEnv(
+					stepper,
+					cloudService,
+					elevenConfig,
+					cluster,
+					env,
+				)
+			}
+
+			return removeEnvWithHooks(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.PreRemoveHook,
+				input.Hooks,
+				postHooksErrs,
+			)
+		},
+	)
+
+	content.Results = results
+	content.PostHooksErrors = postHooksErrs.get()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkBulkEnvResults(results)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return e.outputHandler.HandleOutput(ExpirySweepOutput{
+		Stepper: e.stepper,
+		Content: content,
+	})
+}
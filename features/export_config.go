@@ -0,0 +1,137 @@
+package features
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/eleven-sh/eleven/encryption"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ExportConfigInput struct {
+	Writer      io.Writer
+	SecretsMode string
+	// Required when secrets mode is "encrypted"
+	KeyProvider   encryption.KeyProvider `audit:"-"`
+	ActorResolver entities.ActorResolver
+}
+
+type ExportConfigOutput struct {
+	Error   error
+	Content *ExportConfigOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExportConfigOutputContent struct {
+	ConfigExport *entities.ConfigExport
+}
+
+type ExportConfigOutputHandler interface {
+	HandleOutput(ExportConfigOutput) error
+}
+
+type ExportConfigFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExportConfigOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExportConfigFeature(
+	stepper stepper.Stepper,
+	outputHandler ExportConfigOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExportConfigFeature {
+
+	return ExportConfigFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExportConfigFeature) Execute(input ExportConfigInput) error {
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExportConfigOutput{
+			Stepper: e.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	e.stepper.StartTemporaryStep("Exporting the Eleven config")
+
+	secretsMode := entities.ConfigExportSecretsMode(input.SecretsMode)
+	err := entities.CheckConfigExportSecretsModeValidity(secretsMode)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if secretsMode == entities.ConfigExportSecretsModeEncrypted &&
+		input.KeyProvider == nil {
+
+		return handleError(entities.ErrMissingExportKeyProvider)
+	}
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Exports could contain the SSH keys and secrets of all the envs
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	configExport, err := entities.NewConfigExport(
+		elevenConfig,
+		secretsMode,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if secretsMode == entities.ConfigExportSecretsModeEncrypted {
+		err = encryption.NewConfigEncrypter(input.KeyProvider).Encrypt(
+			configExport.Config,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	encoder := json.NewEncoder(input.Writer)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(configExport)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return e.outputHandler.HandleOutput(ExportConfigOutput{
+		Stepper: e.stepper,
+		Content: &ExportConfigOutputContent{
+			ConfigExport: configExport,
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ExtendInput struct {
+	EnvName       string
+	Duration      time.Duration
+	ActorResolver entities.ActorResolver
+}
+
+type ExtendOutput struct {
+	Error   error
+	Content *ExtendOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExtendOutputContent struct {
+	Cluster   *entities.Cluster
+	Env       *entities.Env
+	ExpiresAt time.Time
+}
+
+type ExtendOutputHandler interface {
+	HandleOutput(ExtendOutput) error
+}
+
+type ExtendFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExtendOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExtendFeature(
+	stepper stepper.Stepper,
+	outputHandler ExtendOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExtendFeature {
+
+	return ExtendFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExtendFeature) Execute(input ExtendInput) error {
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExtendOutput{
+			Stepper: e.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	e.stepper.StartTemporaryStep(
+		fmt.Sprintf("Extending the sandbox \"%s\"", envName),
+	)
+
+	err := entities.CheckEnvTTLValidity(input.Duration)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrExtendRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	err = env.ExtendExpiry(input.Duration, time.Now())
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = actions.UpdateEnvInConfig(
+		e.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return e.outputHandler.HandleOutput(ExtendOutput{
+		Stepper: e.stepper,
+		Content: &ExtendOutputContent{
+			Cluster:   cluster,
+			Env:       env,
+			ExpiresAt: env.GetExpiresAt(),
+		},
+	})
+}
@@ -0,0 +1,151 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type GrantAccessInput struct {
+	EnvName                 string
+	Username                string
+	Role                    string
+	GitHubPublicKeysFetcher entities.GitHubPublicKeysFetcher
+	ActorResolver           entities.ActorResolver
+}
+
+type GrantAccessOutput struct {
+	Error   error
+	Content *GrantAccessOutputContent
+	Stepper stepper.Stepper
+}
+
+type GrantAccessOutputContent struct {
+	Cluster      *entities.Cluster
+	Env          *entities.Env
+	Collaborator *entities.EnvCollaborator
+}
+
+type GrantAccessOutputHandler interface {
+	HandleOutput(GrantAccessOutput) error
+}
+
+type GrantAccessFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       GrantAccessOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewGrantAccessFeature(
+	stepper stepper.Stepper,
+	outputHandler GrantAccessOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) GrantAccessFeature {
+
+	return GrantAccessFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (g GrantAccessFeature) Execute(input GrantAccessInput) error {
+	handleError := func(err error) error {
+		g.outputHandler.HandleOutput(GrantAccessOutput{
+			Stepper: g.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	g.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Granting \"%s\" access to the sandbox \"%s\"",
+			input.Username,
+			input.EnvName,
+		),
+	)
+
+	err := entities.CheckGitHubUsernameValidity(input.Username)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	role, err := entities.ParseEnvCollaboratorRole(input.Role)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	publicKeys, err := input.GitHubPublicKeysFetcher.Fetch(input.Username)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if len(publicKeys) == 0 {
+		return handleError(entities.ErrCollaboratorWithoutPublicKeys{
+			Username: input.Username,
+		})
+	}
+
+	cloudService, err := g.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		g.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	collaborator := entities.NewEnvCollaborator(
+		input.Username,
+		role,
+		publicKeys,
+	)
+
+	cluster, env, err := updateCollaborators(
+		g.stepper,
+		cloudService,
+		elevenConfig,
+		input.EnvName,
+		func(env *entities.Env) error {
+			// Granting access again refreshes
+			// the public keys and the role
+			env.SetCollaborator(collaborator)
+			return nil
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return g.outputHandler.HandleOutput(GrantAccessOutput{
+		Stepper: g.stepper,
+		Content: &GrantAccessOutputContent{
+			Cluster:      cluster,
+			Env:          env,
+			Collaborator: collaborator,
+		},
+	})
+}
@@ -0,0 +1,279 @@
+package features
+
+import (
+	"sync"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// postHooksErrors collects the errors returned by post-hooks.
+// Post-hooks run once the operation is done and saved so their
+// errors are reported in the features outputs instead of
+// making the operation fail.
+type postHooksErrors struct {
+	mutex *sync.Mutex
+	errs  []error
+}
+
+func newPostHooksErrors() *postHooksErrors {
+	return &postHooksErrors{
+		mutex: &sync.Mutex{},
+		errs:  []error{},
+	}
+}
+
+// run runs the post-hooks for the passed payload
+// and collects the returned error, if any
+func (p *postHooksErrors) run(
+	hooks *entities.HookRegistry,
+	payload entities.HookPayload,
+) {
+
+	err := hooks.Run(payload)
+
+	if err == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.errs = append(p.errs, err)
+}
+
+func (p *postHooksErrors) get() []error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]error{}, p.errs...)
+}
+
+// createClusterWithHooks runs the cluster creation
+// hooks around the creation of the passed cluster
+func createClusterWithHooks(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	hooks *entities.HookRegistry,
+	postHooksErrs *postHooksErrors,
+) error {
+
+	payload := entities.HookPayload{
+		Event:        entities.HookEventPreClusterCreate,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      cluster,
+	}
+
+	err := hooks.Run(payload)
+
+	if err != nil {
+		return err
+	}
+
+	err = actions.CreateCluser(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	payload.Event = entities.HookEventPostClusterCreate
+	postHooksErrs.run(hooks, payload)
+
+	return nil
+}
+
+// removeClusterWithHooks runs the cluster removal
+// hooks around the removal of the passed cluster
+func removeClusterWithHooks(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	hooks *entities.HookRegistry,
+	postHooksErrs *postHooksErrors,
+) error {
+
+	payload := entities.HookPayload{
+		Event:        entities.HookEventPreClusterRemove,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      cluster,
+	}
+
+	err := hooks.Run(payload)
+
+	if err != nil {
+		return err
+	}
+
+	err = actions.RemoveCluster(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	payload.Event = entities.HookEventPostClusterRemove
+	postHooksErrs.run(hooks, payload)
+
+	return nil
+}
+
+// removeEnvWithHooks runs the env removal
+// hooks around the removal of the passed env
+func removeEnvWithHooks(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	preRemoveHook entities.HookRunner,
+	hooks *entities.HookRegistry,
+	postHooksErrs *postHooksErrors,
+) error {
+
+	payload := entities.HookPayload{
+		Event:        entities.HookEventPreRemove,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      cluster,
+		Env:          env,
+	}
+
+	err := hooks.Run(payload)
+
+	if err != nil {
+		return err
+	}
+
+	err = actions.RemoveEnv(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		preRemoveHook,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	payload.Event = entities.HookEventPostRemove
+	postHooksErrs.run(hooks, payload)
+
+	return nil
+}
+
+// servePortWithHooks runs the serve hooks
+// around the serving of the passed port
+func servePortWithHooks(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	port string,
+	portBinding string,
+	domainReachabilityChecker entities.DomainReachabilityChecker,
+	hooks *entities.HookRegistry,
+	postHooksErrs *postHooksErrors,
+) (string, error) {
+
+	payload := entities.HookPayload{
+		Event:        entities.HookEventPreServe,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      cluster,
+		Env:          env,
+		ServedPort:   entities.EnvServedPort(port),
+		PortBinding:  portBinding,
+	}
+
+	err := hooks.Run(payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	servedPortBinding, err := actions.ServePort(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		port,
+		portBinding,
+		domainReachabilityChecker,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	payload.Event = entities.HookEventPostServe
+	payload.PortBinding = servedPortBinding
+	postHooksErrs.run(hooks, payload)
+
+	return servedPortBinding, nil
+}
+
+// unservePortWithHooks runs the unserve hooks
+// around the unserving of the passed port
+func unservePortWithHooks(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	port string,
+	hooks *entities.HookRegistry,
+	postHooksErrs *postHooksErrors,
+) error {
+
+	payload := entities.HookPayload{
+		Event:        entities.HookEventPreUnserve,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      cluster,
+		Env:          env,
+		ServedPort:   entities.EnvServedPort(port),
+	}
+
+	err := hooks.Run(payload)
+
+	if err != nil {
+		return err
+	}
+
+	err = actions.UnservePort(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		port,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	payload.Event = entities.HookEventPostUnserve
+	postHooksErrs.run(hooks, payload)
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type IdleSweepInput struct {
+	DryRun         bool
+	MaxConcurrency int
+	ActorResolver  entities.ActorResolver
+}
+
+type IdleSweepOutput struct {
+	Error   error
+	Content *IdleSweepOutputContent
+	Stepper stepper.Stepper
+}
+
+// IdleSweepReportEnv describes the envs that have
+// an idle policy, whether they were stopped or not
+type IdleSweepReportEnv struct {
+	EnvName        string
+	IdlePolicy     *entities.IdlePolicy
+	LastActivityAt time.Time
+	Idle           bool
+	// LookupError is set when the activity could not be retrieved
+	LookupError error
+	// StopError is set when an idle env could not be stopped
+	StopError error
+}
+
+type IdleSweepOutputContent struct {
+	DryRun bool
+	Envs   []IdleSweepReportEnv
+}
+
+type IdleSweepOutputHandler interface {
+	HandleOutput(IdleSweepOutput) error
+}
+
+type IdleSweepFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       IdleSweepOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewIdleSweepFeature(
+	stepper stepper.Stepper,
+	outputHandler IdleSweepOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) IdleSweepFeature {
+
+	return IdleSweepFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (i IdleSweepFeature) Execute(input IdleSweepInput) error {
+	content := &IdleSweepOutputContent{
+		DryRun: input.DryRun,
+		Envs:   []IdleSweepReportEnv{},
+	}
+
+	handleError := func(err error) error {
+		i.outputHandler.HandleOutput(IdleSweepOutput{
+			Stepper: i.stepper,
+			Error:   err,
+			Content: content,
+		})
+
+		return err
+	}
+
+	i.stepper.StartTemporaryStep("Looking for idle sandboxes")
+
+	cloudService, err := i.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		i.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	now := time.Now()
+	envTargets := []actions.EnvTarget{}
+	// Maps the envs to stop to their index in the report
+	reportIndexes := map[string]int{}
+
+	for _, env := range cluster.GetSortedEnvs() {
+		idlePolicy := env.GetEffectiveIdlePolicy(cluster)
+
+		if !idlePolicy.IsEnabled() || env.Status != entities.EnvStatusCreated {
+			continue
+		}
+
+		reportEnv := IdleSweepReportEnv{
+			EnvName:    env.Name,
+			IdlePolicy: idlePolicy,
+		}
+
+		activity, err := cloudService.LookupEnvActivity(
+			i.stepper,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			reportEnv.LookupError = err
+			content.Envs = append(content.Envs, reportEnv)
+			continue
+		}
+
+		reportEnv.LastActivityAt = env.GetLastActivityAt(activity)
+		reportEnv.Idle = env.IsIdle(cluster, activity, now)
+
+		if reportEnv.Idle {
+			reportIndexes[env.Name] = len(content.Envs)
+
+			envTargets = append(envTargets, actions.EnvTarget{
+				Cluster: cluster,
+				Env:     env,
+			})
+		}
+
+		content.Envs = append(content.Envs, reportEnv)
+	}
+
+	if input.DryRun || len(envTargets) == 0 {
+		return i.outputHandler.HandleOutput(IdleSweepOutput{
+			Stepper: i.stepper,
+			Content: content,
+		})
+	}
+
+	i.stepper.StartTemporaryStep("Stopping idle sandboxes")
+
+	results, err := actions.RunEnvOperationsInParallel(
+		i.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency: input.MaxConcurrency,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			return actions.StopEnv(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+			)
+		},
+	)
+
+	for _, result := range results {
+		content.Envs[reportIndexes[result.EnvName]].StopError = result.Error
+	}
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkBulkEnvResults(results)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return i.outputHandler.HandleOutput(IdleSweepOutput{
+		Stepper: i.stepper,
+		Content: content,
+	})
+}
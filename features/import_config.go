@@ -0,0 +1,177 @@
+package features
+
+import (
+	"errors"
+	"io"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/encryption"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ImportConfigInput struct {
+	Reader io.Reader
+	// Required when the config was exported with encrypted secrets
+	KeyProvider encryption.KeyProvider `audit:"-"`
+	// Redacted configs contain envs without SSH keys
+	AllowRedacted bool
+	// Replace a config that already contains clusters
+	Overwrite     bool
+	ActorResolver entities.ActorResolver
+}
+
+type ImportConfigOutput struct {
+	Error   error
+	Content *ImportConfigOutputContent
+	Stepper stepper.Stepper
+}
+
+type ImportConfigOutputContent struct {
+	ElevenConfig    *entities.Config
+	ElevenInstalled bool
+}
+
+type ImportConfigOutputHandler interface {
+	HandleOutput(ImportConfigOutput) error
+}
+
+type ImportConfigFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ImportConfigOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewImportConfigFeature(
+	stepper stepper.Stepper,
+	outputHandler ImportConfigOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ImportConfigFeature {
+
+	return ImportConfigFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (i ImportConfigFeature) Execute(input ImportConfigInput) error {
+	handleError := func(err error) error {
+		i.outputHandler.HandleOutput(ImportConfigOutput{
+			Stepper: i.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	i.stepper.StartTemporaryStep("Importing the Eleven config")
+
+	configExportJSON, err := io.ReadAll(input.Reader)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	configExport, err := entities.ParseConfigExport(configExportJSON)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	importedConfig := configExport.Config
+
+	if configExport.SecretsMode == entities.ConfigExportSecretsModeRedacted &&
+		!input.AllowRedacted {
+
+		return handleError(entities.ErrImportRedactedConfig)
+	}
+
+	if configExport.SecretsMode == entities.ConfigExportSecretsModeEncrypted {
+		if input.KeyProvider == nil {
+			return handleError(entities.ErrMissingExportKeyProvider)
+		}
+
+		err = encryption.NewConfigEncrypter(input.KeyProvider).Decrypt(
+			importedConfig,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	// The config storage will generate its own data key
+	importedConfig.Encryption = nil
+
+	err = importedConfig.CheckConsistency()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := i.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	existingConfig, err := cloudService.LookupElevenConfig(
+		i.stepper,
+	)
+
+	if err != nil && !errors.Is(err, entities.ErrElevenNotInstalled) {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		existingConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenInstalled := false
+
+	if existingConfig == nil { // Eleven not installed
+		i.stepper.StartTemporaryStep("Installing Eleven")
+
+		err = actions.InstallEleven(
+			i.stepper,
+			cloudService,
+			importedConfig,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		elevenInstalled = true
+	} else {
+		if len(existingConfig.Clusters) > 0 && !input.Overwrite {
+			return handleError(entities.ErrImportExistingConfig{
+				NbOfExistingClusters: len(existingConfig.Clusters),
+			})
+		}
+
+		err = cloudService.SaveElevenConfig(
+			i.stepper,
+			importedConfig,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	return i.outputHandler.HandleOutput(ImportConfigOutput{
+		Stepper: i.stepper,
+		Content: &ImportConfigOutputContent{
+			ElevenConfig:    importedConfig,
+			ElevenInstalled: elevenInstalled,
+		},
+	})
+}
@@ -3,6 +3,7 @@ package features
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eleven-sh/eleven/actions"
 	"github.com/eleven-sh/eleven/entities"
@@ -15,6 +16,24 @@ type InitInput struct {
 	LocalSSHCfgDupHostCt int
 	Repositories         []entities.EnvRepository
 	Runtimes             []string
+	GitHubSSHKeyScope    string
+	// TTL is only set on new envs. Zero means no expiry.
+	TTL time.Duration
+	// SnapshotName is set when new envs must
+	// be created from a snapshot's disk
+	SnapshotName string
+	// Owner is the GitHub username of the user that creates
+	// the env. It is resolved using the actor resolver when
+	// set and must match the resolved actor if passed.
+	Owner string
+	// ConfirmProjectedCost is called before
+	// creating anything when the env doesn't exist
+	ConfirmProjectedCost func(entities.CostEstimate) (bool, error)
+	Hooks                *entities.HookRegistry
+	// GitHubDeployKeysCreator is only called for
+	// envs that use deploy keys (see GitHubSSHKeyScope)
+	GitHubDeployKeysCreator entities.GitHubDeployKeysCreator
+	ActorResolver           entities.ActorResolver
 }
 
 type InitOutput struct {
@@ -24,13 +43,19 @@ type InitOutput struct {
 }
 
 type InitOutputContent struct {
-	CloudService    entities.CloudService
-	ElevenConfig    *entities.Config
-	Cluster         *entities.Cluster
-	Env             *entities.Env
-	EnvCreated      bool
+	CloudService entities.CloudService
+	ElevenConfig *entities.Config
+	Cluster      *entities.Cluster
+	Env          *entities.Env
+	EnvCreated   bool
+	// SetEnvAsCreated runs the "env_created" and "post_init"
+	// hooks once the env is saved as created
 	SetEnvAsCreated func() error
 	Runtimes        entities.EnvRuntimes
+	SecretsFiles    []entities.EnvSecretsFile
+	// PostHooksErrors lists the errors returned by post-hooks.
+	// It is updated by SetEnvAsCreated.
+	PostHooksErrors []error
 }
 
 type InitOutputHandler interface {
@@ -83,6 +108,22 @@ func (i InitFeature) Execute(input InitInput) error {
 		return handleError(err)
 	}
 
+	gitHubSSHKeyScope, err := entities.ParseEnvGitHubSSHKeyScope(
+		input.GitHubSSHKeyScope,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if input.TTL != 0 {
+		err = entities.CheckEnvTTLValidity(input.TTL)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
 	cloudService, err := i.cloudServiceBuilder.Build()
 
 	if err != nil {
@@ -106,6 +147,70 @@ func (i InitFeature) Execute(input InitInput) error {
 		return handleError(err)
 	}
 
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	owner, err := resolveOwner(input.ActorResolver, input.Owner)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	envExists := elevenConfig != nil &&
+		elevenConfig.EnvExists(clusterName, envName)
+
+	if !envExists && input.ConfirmProjectedCost != nil {
+		priceTable, err := cloudService.LookupPriceTable(
+			i.stepper,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		// The projected cost is marked as unknown when the
+		// instance type is missing from the offline price table
+		projectedCost := priceTable.EstimateInstanceTypeCost(
+			input.InstanceType,
+		)
+
+		i.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmProjectedCost(projectedCost)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			return nil
+		}
+
+		i.stepper.StartTemporaryStep(step)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	err = input.Hooks.Run(entities.HookPayload{
+		Event:        entities.HookEventPreInit,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+	})
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	if elevenConfig == nil { // Eleven not installed
 
 		i.stepper.StartTemporaryStep("Installing Eleven")
@@ -123,7 +228,6 @@ func (i InitFeature) Execute(input InitInput) error {
 		}
 	}
 
-	clusterName := entities.DefaultClusterName
 	cluster, err := elevenConfig.GetCluster(clusterName)
 
 	if err != nil && !errors.As(err, &entities.ErrClusterNotExists{}) {
@@ -148,11 +252,13 @@ func (i InitFeature) Execute(input InitInput) error {
 			)
 		}
 
-		err = actions.CreateCluser(
+		err = createClusterWithHooks(
 			i.stepper,
 			cloudService,
 			elevenConfig,
 			cluster,
+			input.Hooks,
+			postHooksErrs,
 		)
 
 		if err != nil {
@@ -183,6 +289,22 @@ func (i InitFeature) Execute(input InitInput) error {
 		in creating state after error */
 
 		if env == nil {
+			err = actions.CheckQuotas(
+				i.stepper,
+				cloudService,
+				elevenConfig,
+				entities.QuotasCheckRequest{
+					ClusterName:  cluster.Name,
+					Owner:        owner,
+					InstanceType: input.InstanceType,
+				},
+				time.Now(),
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+
 			env = entities.NewEnv(
 				envName,
 				input.LocalSSHCfgDupHostCt,
@@ -190,6 +312,17 @@ func (i InitFeature) Execute(input InitInput) error {
 				input.Repositories,
 				runtimes,
 			)
+
+			env.GitHubSSHKeyScope = gitHubSSHKeyScope
+			env.Owner = owner
+
+			if input.TTL != 0 {
+				err = env.SetTTL(input.TTL, time.Now())
+
+				if err != nil {
+					return handleError(err)
+				}
+			}
 		} else {
 			if env.InstanceType != input.InstanceType {
 				return handleError(entities.ErrUpdateInstanceTypeCreatingEnv{
@@ -199,9 +332,79 @@ func (i InitFeature) Execute(input InitInput) error {
 
 			env.Repositories = input.Repositories
 			env.Runtimes = runtimes
+			env.GitHubSSHKeyScope = gitHubSSHKeyScope
+		}
+
+		err = env.CheckDeployKeysRepositories()
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if len(input.SnapshotName) > 0 {
+			snapshot, err := cluster.GetSnapshot(input.SnapshotName)
+
+			if err != nil {
+				return handleError(err)
+			}
+
+			if snapshot.Status != entities.SnapshotStatusCreated {
+				return handleError(entities.ErrSnapshotNotCreated{
+					SnapshotName: snapshot.Name,
+					Status:       snapshot.Status,
+				})
+			}
+
+			err = actions.RestoreSnapshot(
+				i.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				snapshot,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+		} else {
+			err = actions.CreateEnv(
+				i.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+		}
+
+		if env.UsesDeployKeys() && input.GitHubDeployKeysCreator != nil {
+			err = actions.CreateDeployKeys(
+				i.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.GitHubDeployKeysCreator,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
 		}
 
-		err = actions.CreateEnv(
+		envCreated = true
+	}
+
+	if env.Status == entities.EnvStatusStopped {
+		i.stepper.StartTemporaryStep(
+			fmt.Sprintf("Starting the sandbox \"%s\"", envName),
+		)
+
+		err = actions.StartEnv(
 			i.stepper,
 			cloudService,
 			elevenConfig,
@@ -212,8 +415,6 @@ func (i InitFeature) Execute(input InitInput) error {
 		if err != nil {
 			return handleError(err)
 		}
-
-		envCreated = true
 	}
 
 	// Current step is the last ended infrastructure step.
@@ -221,28 +422,53 @@ func (i InitFeature) Execute(input InitInput) error {
 	// the next steps (in GRPC agent) may take some time to start.
 	i.stepper.StartTemporaryStep(step)
 
-	setEnvAsCreated := func() error {
-		env.Status = entities.EnvStatusCreated
+	outputContent := &InitOutputContent{
+		CloudService: cloudService,
+		ElevenConfig: elevenConfig,
+		Cluster:      cluster,
+		Env:          env,
+		EnvCreated:   envCreated,
+		Runtimes:     runtimes,
+		SecretsFiles: env.BuildSecretsFiles(),
+	}
+
+	outputContent.SetEnvAsCreated = func() error {
+		env.SetStatus(entities.EnvStatusCreated)
 
-		return actions.UpdateEnvInConfig(
+		err := actions.UpdateEnvInConfig(
 			i.stepper,
 			cloudService,
 			elevenConfig,
 			cluster,
 			env,
 		)
+
+		if err != nil {
+			return err
+		}
+
+		hookPayload := entities.HookPayload{
+			Event:        entities.HookEventEnvCreated,
+			CloudService: cloudService,
+			Config:       elevenConfig,
+			Cluster:      cluster,
+			Env:          env,
+		}
+
+		if envCreated {
+			postHooksErrs.run(input.Hooks, hookPayload)
+		}
+
+		hookPayload.Event = entities.HookEventPostInit
+		postHooksErrs.run(input.Hooks, hookPayload)
+
+		outputContent.PostHooksErrors = postHooksErrs.get()
+
+		return nil
 	}
 
 	return i.outputHandler.HandleOutput(InitOutput{
 		Stepper: i.stepper,
-		Content: &InitOutputContent{
-			CloudService:    cloudService,
-			ElevenConfig:    elevenConfig,
-			Cluster:         cluster,
-			Env:             env,
-			EnvCreated:      envCreated,
-			SetEnvAsCreated: setEnvAsCreated,
-			Runtimes:        runtimes,
-		},
+		Content: outputContent,
 	})
 }
@@ -0,0 +1,78 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+// updateLabels applies the passed label updates on the env
+// named "envName" or, if empty, on the default cluster.
+// Cloud resource tags are then updated accordingly.
+func updateLabels(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	envName string,
+	updateEnvLabels func(*entities.Env) error,
+	updateClusterLabels func(*entities.Cluster) error,
+) (*entities.Cluster, *entities.Env, error) {
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(envName) == 0 {
+		err = updateClusterLabels(cluster)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = actions.UpdateClusterTags(
+			stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+		)
+
+		return cluster, nil, err
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return nil, nil, entities.ErrLabelsRemovingEnv{
+			EnvName: envName,
+		}
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return nil, nil, entities.ErrLabelsCreatingEnv{
+			EnvName: envName,
+		}
+	}
+
+	err = updateEnvLabels(env)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = actions.UpdateEnvTags(
+		stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	return cluster, env, err
+}
@@ -0,0 +1,198 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ListInput struct {
+	// All the envs are listed when the selector is empty
+	Selector entities.EnvSelector
+	// Namespace is set to list the envs of all the clusters
+	// in a namespace instead of the default cluster ones
+	Namespace     string
+	ActorResolver entities.ActorResolver
+}
+
+type ListOutput struct {
+	Error   error
+	Content *ListOutputContent
+	Stepper stepper.Stepper
+}
+
+type ListOutputContent struct {
+	Cluster *entities.Cluster
+	Envs    []*entities.Env
+	// Namespace and ClusterEnvs are only set
+	// when envs are listed by namespace
+	Namespace   *entities.Namespace
+	ClusterEnvs []ListClusterEnvs
+}
+
+type ListClusterEnvs struct {
+	Cluster *entities.Cluster
+	Envs    []*entities.Env
+}
+
+type ListOutputHandler interface {
+	HandleOutput(ListOutput) error
+}
+
+type ListFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ListOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewListFeature(
+	stepper stepper.Stepper,
+	outputHandler ListOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ListFeature {
+
+	return ListFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (l ListFeature) Execute(input ListInput) error {
+	handleError := func(err error) error {
+		l.outputHandler.HandleOutput(ListOutput{
+			Stepper: l.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	l.stepper.StartTemporaryStep("Listing your sandboxes")
+
+	if !input.Selector.IsEmpty() {
+		err := entities.CheckEnvSelectorValidity(input.Selector)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	cloudService, err := l.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		l.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if len(input.Namespace) > 0 {
+		err = checkNamespacePermission(
+			elevenConfig,
+			input.ActorResolver,
+			entities.PermissionRead,
+			input.Namespace,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		namespace, clusterEnvs, err := l.listNamespaceEnvs(
+			elevenConfig,
+			input.Namespace,
+			input.Selector,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		return l.outputHandler.HandleOutput(ListOutput{
+			Stepper: l.stepper,
+			Content: &ListOutputContent{
+				Namespace:   namespace,
+				ClusterEnvs: clusterEnvs,
+			},
+		})
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionRead,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	envs, err := elevenConfig.SelectEnvs(
+		cluster.Name,
+		input.Selector,
+		time.Now(),
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return l.outputHandler.HandleOutput(ListOutput{
+		Stepper: l.stepper,
+		Content: &ListOutputContent{
+			Cluster: cluster,
+			Envs:    envs,
+		},
+	})
+}
+
+func (l ListFeature) listNamespaceEnvs(
+	elevenConfig *entities.Config,
+	namespaceName string,
+	selector entities.EnvSelector,
+) (*entities.Namespace, []ListClusterEnvs, error) {
+
+	namespace, err := elevenConfig.GetNamespace(namespaceName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterEnvs := []ListClusterEnvs{}
+	now := time.Now()
+
+	for _, cluster := range elevenConfig.GetClustersInNamespace(namespace.Name) {
+		envs, err := elevenConfig.SelectEnvs(
+			cluster.Name,
+			selector,
+			now,
+		)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		clusterEnvs = append(clusterEnvs, ListClusterEnvs{
+			Cluster: cluster,
+			Envs:    envs,
+		})
+	}
+
+	return namespace, clusterEnvs, nil
+}
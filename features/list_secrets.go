@@ -0,0 +1,130 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ListSecretsInput struct {
+	EnvName       string
+	ActorResolver entities.ActorResolver
+}
+
+type ListSecretsOutput struct {
+	Error   error
+	Content *ListSecretsOutputContent
+	Stepper stepper.Stepper
+}
+
+// ListSecretsOutputSecret describes a secret without its value
+type ListSecretsOutputSecret struct {
+	Name               string
+	Scope              entities.EnvSecretScope
+	UpdatedAtTimestamp int64
+}
+
+type ListSecretsOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	Secrets []ListSecretsOutputSecret
+}
+
+type ListSecretsOutputHandler interface {
+	HandleOutput(ListSecretsOutput) error
+}
+
+type ListSecretsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ListSecretsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewListSecretsFeature(
+	stepper stepper.Stepper,
+	outputHandler ListSecretsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ListSecretsFeature {
+
+	return ListSecretsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (l ListSecretsFeature) Execute(input ListSecretsInput) error {
+	handleError := func(err error) error {
+		l.outputHandler.HandleOutput(ListSecretsOutput{
+			Stepper: l.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	l.stepper.StartTemporaryStep(
+		fmt.Sprintf("Listing the secrets of the sandbox \"%s\"", envName),
+	)
+
+	cloudService, err := l.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		l.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionRead,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	secrets := []ListSecretsOutputSecret{}
+
+	for _, secret := range env.GetSortedSecrets() {
+		secrets = append(secrets, ListSecretsOutputSecret{
+			Name:               secret.Name,
+			Scope:              secret.Scope,
+			UpdatedAtTimestamp: secret.UpdatedAtTimestamp,
+		})
+	}
+
+	return l.outputHandler.HandleOutput(ListSecretsOutput{
+		Stepper: l.stepper,
+		Content: &ListSecretsOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Secrets: secrets,
+		},
+	})
+}
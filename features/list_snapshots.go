@@ -0,0 +1,130 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ListSnapshotsInput struct {
+	ActorResolver entities.ActorResolver
+}
+
+type ListSnapshotsOutput struct {
+	Error   error
+	Content *ListSnapshotsOutputContent
+	Stepper stepper.Stepper
+}
+
+type ListSnapshotsOutputContent struct {
+	Cluster   *entities.Cluster
+	Snapshots []*entities.Snapshot
+	// MissingSnapshotNames lists the snapshots stored in
+	// the config that no longer exist in the cloud service
+	MissingSnapshotNames []string
+}
+
+type ListSnapshotsOutputHandler interface {
+	HandleOutput(ListSnapshotsOutput) error
+}
+
+type ListSnapshotsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ListSnapshotsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewListSnapshotsFeature(
+	stepper stepper.Stepper,
+	outputHandler ListSnapshotsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ListSnapshotsFeature {
+
+	return ListSnapshotsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (l ListSnapshotsFeature) Execute(input ListSnapshotsInput) error {
+	handleError := func(err error) error {
+		l.outputHandler.HandleOutput(ListSnapshotsOutput{
+			Stepper: l.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	l.stepper.StartTemporaryStep("Listing your snapshots")
+
+	cloudService, err := l.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		l.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionRead,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudSnapshots, err := cloudService.ListSnapshots(
+		l.stepper,
+		elevenConfig,
+		cluster,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudSnapshotIDs := map[string]bool{}
+	for _, cloudSnapshot := range cloudSnapshots {
+		cloudSnapshotIDs[cloudSnapshot.ID] = true
+	}
+
+	snapshots := cluster.GetSortedSnapshots()
+	missingSnapshotNames := []string{}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Status != entities.SnapshotStatusCreated ||
+			cloudSnapshotIDs[snapshot.ID] {
+
+			continue
+		}
+
+		missingSnapshotNames = append(missingSnapshotNames, snapshot.Name)
+	}
+
+	return l.outputHandler.HandleOutput(ListSnapshotsOutput{
+		Stepper: l.stepper,
+		Content: &ListSnapshotsOutputContent{
+			Cluster:              cluster,
+			Snapshots:            snapshots,
+			MissingSnapshotNames: missingSnapshotNames,
+		},
+	})
+}
@@ -0,0 +1,355 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type MoveEnvInput struct {
+	EnvName string
+	// SourceClusterName defaults to the default cluster
+	SourceClusterName string
+	TargetClusterName string
+	// EnvHealthChecker is called once the env is created in the
+	// target cluster. The source env is only removed if the
+	// moved env is healthy. Required unless ForceMove is set.
+	EnvHealthChecker entities.EnvHealthChecker
+	// ForceMove removes the source env without
+	// checking the health of the moved env
+	ForceMove bool
+	// Hooks receive the "env_created" event for the moved
+	// env and the removal events for the source env
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
+}
+
+type MoveEnvOutput struct {
+	Error   error
+	Content *MoveEnvOutputContent
+	Stepper stepper.Stepper
+}
+
+type MoveEnvOutputContent struct {
+	SourceCluster *entities.Cluster
+	TargetCluster *entities.Cluster
+	SourceEnv     *entities.Env
+	Env           *entities.Env
+	// DomainsToUpdate lists the served domains that
+	// must resolve to the new public IP address of the env
+	DomainsToUpdate []string
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The env is moved in any case.
+	PostHooksErrors []error
+}
+
+type MoveEnvOutputHandler interface {
+	HandleOutput(MoveEnvOutput) error
+}
+
+type MoveEnvFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       MoveEnvOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewMoveEnvFeature(
+	stepper stepper.Stepper,
+	outputHandler MoveEnvOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) MoveEnvFeature {
+
+	return MoveEnvFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (m MoveEnvFeature) Execute(input MoveEnvInput) error {
+	handleError := func(err error) error {
+		m.outputHandler.HandleOutput(MoveEnvOutput{
+			Stepper: m.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+	sourceClusterName := input.SourceClusterName
+
+	if len(sourceClusterName) == 0 {
+		sourceClusterName = entities.DefaultClusterName
+	}
+
+	step := fmt.Sprintf(
+		"Moving the sandbox \"%s\" to the cluster \"%s\"",
+		envName,
+		input.TargetClusterName,
+	)
+
+	m.stepper.StartTemporaryStep(step)
+
+	if sourceClusterName == input.TargetClusterName {
+		return handleError(entities.ErrMoveSameCluster{
+			ClusterName: sourceClusterName,
+		})
+	}
+
+	// The source env can't be restored once removed
+	if input.EnvHealthChecker == nil && !input.ForceMove {
+		return handleError(entities.ErrMoveHealthCheckRequired{
+			EnvName: envName,
+		})
+	}
+
+	cloudService, err := m.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		m.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	sourceCluster, err := elevenConfig.GetCluster(sourceClusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	targetCluster, err := elevenConfig.GetCluster(input.TargetClusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if targetCluster.Status != entities.ClusterStatusCreated {
+		return handleError(entities.ErrClusterNotExists{
+			ClusterName: targetCluster.Name,
+		})
+	}
+
+	// The temporary snapshot is taken in the source cluster
+	crossClusterRestorer, ok := entities.LookupCloudServiceCrossClusterRestorer(
+		cloudService,
+	)
+
+	if !ok {
+		return handleError(entities.ErrMoveUnsupported{
+			SourceClusterName: sourceCluster.Name,
+			TargetClusterName: targetCluster.Name,
+		})
+	}
+
+	err = crossClusterRestorer.CheckCrossClusterRestore(
+		m.stepper,
+		elevenConfig,
+		sourceCluster,
+		targetCluster,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	sourceEnv, err := elevenConfig.GetEnv(sourceCluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if sourceEnv.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrMoveRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if sourceEnv.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrMoveCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	env, err := elevenConfig.GetEnv(targetCluster.Name, envName)
+
+	if err != nil && !errors.As(err, &entities.ErrEnvNotExists{}) {
+		return handleError(err)
+	}
+
+	// Envs still in creating state
+	// after error could be moved again
+	if env != nil && env.Status != entities.EnvStatusCreating {
+		return handleError(entities.ErrEnvAlreadyExists{
+			ClusterName: targetCluster.Name,
+			EnvName:     env.Name,
+		})
+	}
+
+	if env == nil {
+		err = actions.CheckQuotas(
+			m.stepper,
+			cloudService,
+			elevenConfig,
+			entities.QuotasCheckRequest{
+				ClusterName:  targetCluster.Name,
+				Owner:        sourceEnv.Owner,
+				InstanceType: sourceEnv.InstanceType,
+				// The source env is removed once moved
+				MovedEnv: sourceEnv,
+			},
+			time.Now(),
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		env, err = entities.NewEnvForMove(sourceEnv)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	err = actions.CreateEnvFromTemporarySnapshot(
+		m.stepper,
+		cloudService,
+		elevenConfig,
+		sourceCluster,
+		sourceEnv,
+		targetCluster,
+		env,
+		"move-"+env.Name,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	portBoundServedPorts := env.GetPortBoundServedPorts()
+
+	for _, servedPort := range portBoundServedPorts.GetSortedPorts() {
+		for _, binding := range portBoundServedPorts[servedPort] {
+			err = actions.OpenPort(
+				m.stepper,
+				cloudService,
+				elevenConfig,
+				targetCluster,
+				env,
+				binding.Value,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+		}
+	}
+
+	if input.EnvHealthChecker != nil {
+		m.stepper.StartTemporaryStep(
+			fmt.Sprintf(
+				"Checking that the sandbox \"%s\" is healthy in the cluster \"%s\"",
+				envName,
+				targetCluster.Name,
+			),
+		)
+
+		healthy, err := input.EnvHealthChecker.Check(env)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !healthy {
+			return handleError(entities.ErrMoveUnhealthyEnv{
+				ClusterName: targetCluster.Name,
+				EnvName:     env.Name,
+			})
+		}
+	}
+
+	env.SetStatus(entities.EnvStatusCreated)
+	err = actions.UpdateEnvInConfig(
+		m.stepper,
+		cloudService,
+		elevenConfig,
+		targetCluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	postHooksErrs.run(input.Hooks, entities.HookPayload{
+		Event:        entities.HookEventEnvCreated,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+		Cluster:      targetCluster,
+		Env:          env,
+	})
+
+	// The GitHub keys are not removed given
+	// that they are shared with the moved env
+	var preRemoveHook entities.HookRunner
+
+	err = removeEnvWithHooks(
+		m.stepper,
+		cloudService,
+		elevenConfig,
+		sourceCluster,
+		sourceEnv,
+		preRemoveHook,
+		input.Hooks,
+		postHooksErrs,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	domainsToUpdate := []string{}
+
+	for _, servedPort := range env.ServedPorts.GetSortedPorts() {
+		for _, binding := range env.ServedPorts[servedPort] {
+			if binding.Type == entities.EnvServedPortBindingTypeDomain {
+				domainsToUpdate = append(domainsToUpdate, binding.Value)
+			}
+		}
+	}
+
+	return m.outputHandler.HandleOutput(MoveEnvOutput{
+		Stepper: m.stepper,
+		Content: &MoveEnvOutputContent{
+			SourceCluster:   sourceCluster,
+			TargetCluster:   targetCluster,
+			SourceEnv:       sourceEnv,
+			Env:             env,
+			DomainsToUpdate: domainsToUpdate,
+			PostHooksErrors: postHooksErrs.get(),
+		},
+	})
+}
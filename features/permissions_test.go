@@ -0,0 +1,179 @@
+package features
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type fakeStep struct{}
+
+func (fakeStep) Done() {}
+
+type fakeStepper struct{}
+
+func (fakeStepper) StartStep(string) stepper.Step {
+	return fakeStep{}
+}
+
+func (fakeStepper) StartTemporaryStep(string) stepper.Step {
+	return fakeStep{}
+}
+
+func (fakeStepper) StartTemporaryStepWithoutNewLine(string) stepper.Step {
+	return fakeStep{}
+}
+
+func (fakeStepper) StopCurrentStep() {}
+
+type fakeOutputHandler[O any] struct{}
+
+func (fakeOutputHandler[O]) HandleOutput(O) error {
+	return nil
+}
+
+type fakeCloudService struct {
+	entities.CloudService
+	elevenConfig *entities.Config
+}
+
+func (f fakeCloudService) LookupElevenConfig(
+	stepper.Stepper,
+) (*entities.Config, error) {
+
+	return f.elevenConfig, nil
+}
+
+type fakeCloudServiceBuilder struct {
+	cloudService entities.CloudService
+}
+
+func (f fakeCloudServiceBuilder) Build() (entities.CloudService, error) {
+	return f.cloudService, nil
+}
+
+func TestReadFeaturesRejectNonMembers(t *testing.T) {
+	elevenConfig := entities.NewConfig()
+	elevenConfig.Clusters[entities.DefaultClusterName] = entities.NewCluster(
+		entities.DefaultClusterName,
+		"t2.medium",
+		true,
+	)
+
+	err := elevenConfig.SetMember(
+		entities.NewConfigMember("alice", entities.MemberRoleAdmin),
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	s := fakeStepper{}
+	builder := fakeCloudServiceBuilder{
+		cloudService: fakeCloudService{elevenConfig: elevenConfig},
+	}
+	nonMember := fakeActorResolver{actor: "mallory"}
+
+	testCases := []struct {
+		test    string
+		execute func() error
+	}{
+		{
+			test: "with export config",
+			execute: func() error {
+				return NewExportConfigFeature(
+					s,
+					fakeOutputHandler[ExportConfigOutput]{},
+					builder,
+				).Execute(ExportConfigInput{
+					Writer:        &bytes.Buffer{},
+					SecretsMode:   string(entities.ConfigExportSecretsModePlaintext),
+					ActorResolver: nonMember,
+				})
+			},
+		},
+
+		{
+			test: "with list",
+			execute: func() error {
+				return NewListFeature(
+					s,
+					fakeOutputHandler[ListOutput]{},
+					builder,
+				).Execute(ListInput{
+					ActorResolver: nonMember,
+				})
+			},
+		},
+
+		{
+			test: "with list secrets",
+			execute: func() error {
+				return NewListSecretsFeature(
+					s,
+					fakeOutputHandler[ListSecretsOutput]{},
+					builder,
+				).Execute(ListSecretsInput{
+					EnvName:       "env",
+					ActorResolver: nonMember,
+				})
+			},
+		},
+
+		{
+			test: "with list snapshots",
+			execute: func() error {
+				return NewListSnapshotsFeature(
+					s,
+					fakeOutputHandler[ListSnapshotsOutput]{},
+					builder,
+				).Execute(ListSnapshotsInput{
+					ActorResolver: nonMember,
+				})
+			},
+		},
+
+		{
+			test: "with cost",
+			execute: func() error {
+				return NewCostFeature(
+					s,
+					fakeOutputHandler[CostOutput]{},
+					builder,
+				).Execute(CostInput{
+					ActorResolver: nonMember,
+				})
+			},
+		},
+
+		{
+			test: "with audit log",
+			execute: func() error {
+				return NewAuditLogFeature(
+					s,
+					fakeOutputHandler[AuditLogOutput]{},
+					builder,
+				).Execute(AuditLogInput{
+					ActorResolver: nonMember,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := tc.execute()
+
+			if !errors.As(err, &entities.ErrPermissionDenied{}) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					entities.ErrPermissionDenied{},
+					err,
+				)
+			}
+		})
+	}
+}
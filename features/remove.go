@@ -3,7 +3,6 @@ package features
 import (
 	"fmt"
 
-	"github.com/eleven-sh/eleven/actions"
 	"github.com/eleven-sh/eleven/entities"
 	"github.com/eleven-sh/eleven/stepper"
 )
@@ -13,6 +12,8 @@ type RemoveInput struct {
 	PreRemoveHook entities.HookRunner
 	ForceRemove   bool
 	ConfirmRemove func() (bool, error)
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
 }
 
 type RemoveOutput struct {
@@ -24,6 +25,9 @@ type RemoveOutput struct {
 type RemoveOutputContent struct {
 	Cluster *entities.Cluster
 	Env     *entities.Env
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The env is removed in any case.
+	PostHooksErrors []error
 }
 
 type RemoveOutputHandler interface {
@@ -79,6 +83,18 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 	}
 
 	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	cluster, err := elevenConfig.GetCluster(clusterName)
 
 	if err != nil {
@@ -107,13 +123,17 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 		r.stepper.StartTemporaryStep(step)
 	}
 
-	err = actions.RemoveEnv(
+	postHooksErrs := newPostHooksErrors()
+
+	err = removeEnvWithHooks(
 		r.stepper,
 		cloudService,
 		elevenConfig,
 		cluster,
 		env,
 		input.PreRemoveHook,
+		input.Hooks,
+		postHooksErrs,
 	)
 
 	if err != nil {
@@ -123,8 +143,9 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 	return r.outputHandler.HandleOutput(RemoveOutput{
 		Stepper: r.stepper,
 		Content: &RemoveOutputContent{
-			Cluster: cluster,
-			Env:     env,
+			Cluster:         cluster,
+			Env:             env,
+			PostHooksErrors: postHooksErrs.get(),
 		},
 	})
 }
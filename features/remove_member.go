@@ -0,0 +1,132 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RemoveMemberInput struct {
+	Username string
+	// Namespace is empty when the member is
+	// removed from the whole installation
+	Namespace     string
+	ActorResolver entities.ActorResolver
+}
+
+type RemoveMemberOutput struct {
+	Error   error
+	Content *RemoveMemberOutputContent
+	Stepper stepper.Stepper
+}
+
+type RemoveMemberOutputContent struct {
+	Members []*entities.ConfigMember
+}
+
+type RemoveMemberOutputHandler interface {
+	HandleOutput(RemoveMemberOutput) error
+}
+
+type RemoveMemberFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RemoveMemberOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRemoveMemberFeature(
+	stepper stepper.Stepper,
+	outputHandler RemoveMemberOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RemoveMemberFeature {
+
+	return RemoveMemberFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RemoveMemberFeature) Execute(input RemoveMemberInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RemoveMemberOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	r.stepper.StartTemporaryStep(
+		fmt.Sprintf("Removing the member \"%s\"", input.Username),
+	)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Namespace admins manage the members of their namespace
+	err = checkNamespacePermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+		input.Namespace,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var members entities.ConfigMembers
+
+	if len(input.Namespace) > 0 {
+		namespace, err := elevenConfig.GetNamespace(input.Namespace)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		err = namespace.RemoveMember(input.Username)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		members = namespace.Members
+	} else {
+		err = elevenConfig.RemoveMember(input.Username)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		members = elevenConfig.Members
+	}
+
+	err = cloudService.SaveElevenConfig(
+		r.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(RemoveMemberOutput{
+		Stepper: r.stepper,
+		Content: &RemoveMemberOutputContent{
+			Members: members.GetSorted(),
+		},
+	})
+}
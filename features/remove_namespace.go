@@ -0,0 +1,109 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RemoveNamespaceInput struct {
+	Name          string
+	ActorResolver entities.ActorResolver
+}
+
+type RemoveNamespaceOutput struct {
+	Error   error
+	Content *RemoveNamespaceOutputContent
+	Stepper stepper.Stepper
+}
+
+type RemoveNamespaceOutputContent struct {
+	Namespaces []*entities.Namespace
+}
+
+type RemoveNamespaceOutputHandler interface {
+	HandleOutput(RemoveNamespaceOutput) error
+}
+
+type RemoveNamespaceFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RemoveNamespaceOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRemoveNamespaceFeature(
+	stepper stepper.Stepper,
+	outputHandler RemoveNamespaceOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RemoveNamespaceFeature {
+
+	return RemoveNamespaceFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RemoveNamespaceFeature) Execute(input RemoveNamespaceInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RemoveNamespaceOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	r.stepper.StartTemporaryStep(
+		fmt.Sprintf("Removing the namespace \"%s\"", input.Name),
+	)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Clusters must be moved to other
+	// namespaces before removing a namespace
+	err = elevenConfig.RemoveNamespace(input.Name)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = cloudService.SaveElevenConfig(
+		r.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(RemoveNamespaceOutput{
+		Stepper: r.stepper,
+		Content: &RemoveNamespaceOutputContent{
+			Namespaces: elevenConfig.GetSortedNamespaces(),
+		},
+	})
+}
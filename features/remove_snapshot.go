@@ -0,0 +1,139 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RemoveSnapshotInput struct {
+	SnapshotName  string
+	ForceRemove   bool
+	ConfirmRemove func() (bool, error)
+	ActorResolver entities.ActorResolver
+}
+
+type RemoveSnapshotOutput struct {
+	Error   error
+	Content *RemoveSnapshotOutputContent
+	Stepper stepper.Stepper
+}
+
+type RemoveSnapshotOutputContent struct {
+	Cluster  *entities.Cluster
+	Snapshot *entities.Snapshot
+}
+
+type RemoveSnapshotOutputHandler interface {
+	HandleOutput(RemoveSnapshotOutput) error
+}
+
+type RemoveSnapshotFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RemoveSnapshotOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRemoveSnapshotFeature(
+	stepper stepper.Stepper,
+	outputHandler RemoveSnapshotOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RemoveSnapshotFeature {
+
+	return RemoveSnapshotFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RemoveSnapshotFeature) Execute(input RemoveSnapshotInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RemoveSnapshotOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	step := fmt.Sprintf("Removing the snapshot \"%s\"", input.SnapshotName)
+	r.stepper.StartTemporaryStep(step)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	snapshot, err := cluster.GetSnapshot(input.SnapshotName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if !input.ForceRemove && input.ConfirmRemove != nil {
+		r.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmRemove()
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			return nil
+		}
+
+		r.stepper.StartTemporaryStep(step)
+	}
+
+	err = actions.RemoveSnapshot(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		snapshot,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(RemoveSnapshotOutput{
+		Stepper: r.stepper,
+		Content: &RemoveSnapshotOutputContent{
+			Cluster:  cluster,
+			Snapshot: snapshot,
+		},
+	})
+}
@@ -0,0 +1,208 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RenameInput struct {
+	EnvName              string
+	NewEnvName           string
+	LocalSSHCfgDupHostCt int
+	GitHubSSHKeyRenamer  entities.GitHubSSHKeyRenamer
+	ActorResolver        entities.ActorResolver
+}
+
+type RenameOutput struct {
+	Error   error
+	Content *RenameOutputContent
+	Stepper stepper.Stepper
+}
+
+type RenameOutputContent struct {
+	Cluster         *entities.Cluster
+	Env             *entities.Env
+	PreviousEnvName string
+	// PreviousLocalSSHConfigHostname is returned so that
+	// the caller could update the local SSH config
+	PreviousLocalSSHConfigHostname string
+}
+
+type RenameOutputHandler interface {
+	HandleOutput(RenameOutput) error
+}
+
+type RenameFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RenameOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRenameFeature(
+	stepper stepper.Stepper,
+	outputHandler RenameOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RenameFeature {
+
+	return RenameFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RenameFeature) Execute(input RenameInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RenameOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	r.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Renaming the sandbox \"%s\" to \"%s\"",
+			envName,
+			input.NewEnvName,
+		),
+	)
+
+	err := entities.CheckEnvNameValidity(input.NewEnvName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrRenameRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrRenameCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	previousLocalSSHConfigHostname := env.LocalSSHConfigHostname
+	previousSSHKeyPairName := env.GetSSHKeyPairName()
+
+	if envName == input.NewEnvName {
+		return r.outputHandler.HandleOutput(RenameOutput{
+			Stepper: r.stepper,
+			Content: &RenameOutputContent{
+				Cluster:                        cluster,
+				Env:                            env,
+				PreviousEnvName:                envName,
+				PreviousLocalSSHConfigHostname: previousLocalSSHConfigHostname,
+			},
+		})
+	}
+
+	err = elevenConfig.RenameEnv(
+		cluster.Name,
+		envName,
+		input.NewEnvName,
+		input.LocalSSHCfgDupHostCt,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Cloud resources are not recreated,
+	// only their tags are updated
+	err = actions.UpdateEnvTags(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if input.GitHubSSHKeyRenamer != nil {
+		renameKeyErr := input.GitHubSSHKeyRenamer.Rename(
+			env,
+			previousSSHKeyPairName,
+		)
+
+		// "renameKeyErr" is not handled first
+		// in order to save the updated deploy keys
+		err = actions.UpdateEnvInConfig(
+			r.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if renameKeyErr != nil {
+			return handleError(renameKeyErr)
+		}
+	}
+
+	return r.outputHandler.HandleOutput(RenameOutput{
+		Stepper: r.stepper,
+		Content: &RenameOutputContent{
+			Cluster:                        cluster,
+			Env:                            env,
+			PreviousEnvName:                envName,
+			PreviousLocalSSHConfigHostname: previousLocalSSHConfigHostname,
+		},
+	})
+}
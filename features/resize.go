@@ -0,0 +1,187 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type ResizeInput struct {
+	EnvName       string
+	InstanceType  string
+	ActorResolver entities.ActorResolver
+}
+
+type ResizeOutput struct {
+	Error   error
+	Content *ResizeOutputContent
+	Stepper stepper.Stepper
+}
+
+type ResizeOutputContent struct {
+	Cluster              *entities.Cluster
+	Env                  *entities.Env
+	PreviousInstanceType string
+	EnvAlreadyResized    bool
+}
+
+type ResizeOutputHandler interface {
+	HandleOutput(ResizeOutput) error
+}
+
+type ResizeFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ResizeOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewResizeFeature(
+	stepper stepper.Stepper,
+	outputHandler ResizeOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ResizeFeature {
+
+	return ResizeFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r ResizeFeature) Execute(input ResizeInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(ResizeOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	r.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Resizing the sandbox \"%s\" to \"%s\"",
+			envName,
+			input.InstanceType,
+		),
+	)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = cloudService.CheckInstanceTypeValidity(
+		r.stepper,
+		input.InstanceType,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrResizeRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrResizeCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	previousInstanceType := env.InstanceType
+
+	if previousInstanceType == input.InstanceType {
+		return r.outputHandler.HandleOutput(ResizeOutput{
+			Stepper: r.stepper,
+			Content: &ResizeOutputContent{
+				Cluster:              cluster,
+				Env:                  env,
+				PreviousInstanceType: previousInstanceType,
+				EnvAlreadyResized:    true,
+			},
+		})
+	}
+
+	err = actions.CheckQuotas(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		entities.QuotasCheckRequest{
+			ClusterName:  cluster.Name,
+			Owner:        env.Owner,
+			InstanceType: input.InstanceType,
+			ReplacedEnv:  env,
+		},
+		time.Now(),
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = actions.ResizeEnv(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		input.InstanceType,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(ResizeOutput{
+		Stepper: r.stepper,
+		Content: &ResizeOutputContent{
+			Cluster:              cluster,
+			Env:                  env,
+			PreviousInstanceType: previousInstanceType,
+			EnvAlreadyResized:    false,
+		},
+	})
+}
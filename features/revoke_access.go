@@ -0,0 +1,114 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RevokeAccessInput struct {
+	EnvName       string
+	Username      string
+	ActorResolver entities.ActorResolver
+}
+
+type RevokeAccessOutput struct {
+	Error   error
+	Content *RevokeAccessOutputContent
+	Stepper stepper.Stepper
+}
+
+type RevokeAccessOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+}
+
+type RevokeAccessOutputHandler interface {
+	HandleOutput(RevokeAccessOutput) error
+}
+
+type RevokeAccessFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RevokeAccessOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRevokeAccessFeature(
+	stepper stepper.Stepper,
+	outputHandler RevokeAccessOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RevokeAccessFeature {
+
+	return RevokeAccessFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RevokeAccessFeature) Execute(input RevokeAccessInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RevokeAccessOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	r.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Revoking \"%s\" access to the sandbox \"%s\"",
+			input.Username,
+			input.EnvName,
+		),
+	)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, env, err := updateCollaborators(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		input.EnvName,
+		func(env *entities.Env) error {
+			return env.RemoveCollaborator(input.Username)
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(RevokeAccessOutput{
+		Stepper: r.stepper,
+		Content: &RevokeAccessOutputContent{
+			Cluster: cluster,
+			Env:     env,
+		},
+	})
+}
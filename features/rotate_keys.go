@@ -0,0 +1,258 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type RotateKeysInput struct {
+	EnvName             string
+	GitHubSSHKeySwapper entities.GitHubSSHKeySwapper
+	// ConfirmRotation is called once the new key is authorized
+	// on the instance (alongside the old one) and should
+	// return true only if the new key could be used.
+	ConfirmRotation func(env *entities.Env, newSSHKeyPairPEMContent string) (bool, error)
+	ActorResolver   entities.ActorResolver
+}
+
+type RotateKeysOutput struct {
+	Error   error
+	Content *RotateKeysOutputContent
+	Stepper stepper.Stepper
+}
+
+type RotateKeysOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+}
+
+type RotateKeysOutputHandler interface {
+	HandleOutput(RotateKeysOutput) error
+}
+
+type RotateKeysFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RotateKeysOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRotateKeysFeature(
+	stepper stepper.Stepper,
+	outputHandler RotateKeysOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RotateKeysFeature {
+
+	return RotateKeysFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RotateKeysFeature) Execute(input RotateKeysInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RotateKeysOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	step := fmt.Sprintf("Rotating the SSH keys of the sandbox \"%s\"", envName)
+	r.stepper.StartTemporaryStep(step)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrRotateKeysRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrRotateKeysCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	oldPublicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// A pending key means that a previous rotation
+	// was interrupted. The pending key is reused given
+	// that it may already be authorized on the instance
+	// and registered on GitHub (keys are looked up by
+	// content before being registered again).
+	if !env.IsRotatingSSHKey() {
+		newSSHKeyPairPEMContent, err := entities.GenerateSSHKeyPairPEMContent()
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		env.StartSSHKeyRotation(newSSHKeyPairPEMContent)
+
+		err = actions.UpdateEnvInConfig(
+			r.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	newSSHKeyPairPEMContent := env.PendingSSHKeyPairPEMContent
+
+	newPublicKeyContent, err := entities.BuildSSHPublicKeyContent(
+		newSSHKeyPairPEMContent,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Both keys are authorized during the switch
+	err = actions.UpdateEnvAuthorizedKeys(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if input.ConfirmRotation != nil {
+		confirmed, err := input.ConfirmRotation(
+			env,
+			newSSHKeyPairPEMContent,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			env.CancelSSHKeyRotation()
+
+			err = actions.UpdateEnvAuthorizedKeys(
+				r.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+
+			return handleError(entities.ErrSSHKeyRotationNotConfirmed{
+				EnvName: envName,
+			})
+		}
+
+		r.stepper.StartTemporaryStep(step)
+	}
+
+	if input.GitHubSSHKeySwapper != nil {
+		swapErr := input.GitHubSSHKeySwapper.Swap(
+			env,
+			oldPublicKeyContent,
+			newPublicKeyContent,
+		)
+
+		// The deploy keys swapped before the error
+		// are saved to not lose track of their IDs
+		if swapErr != nil {
+			err = actions.UpdateEnvInConfig(
+				r.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+
+			return handleError(swapErr)
+		}
+	}
+
+	env.ConfirmSSHKeyRotation()
+
+	// Only the new key is authorized from now on
+	err = actions.UpdateEnvAuthorizedKeys(
+		r.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return r.outputHandler.HandleOutput(RotateKeysOutput{
+		Stepper: r.stepper,
+		Content: &RotateKeysOutputContent{
+			Cluster: cluster,
+			Env:     env,
+		},
+	})
+}
@@ -3,8 +3,6 @@ package features
 import (
 	"fmt"
 
-	"github.com/asaskevich/govalidator"
-	"github.com/eleven-sh/eleven/actions"
 	"github.com/eleven-sh/eleven/entities"
 	"github.com/eleven-sh/eleven/stepper"
 )
@@ -15,6 +13,8 @@ type ServeInput struct {
 	Port                      string
 	PortBinding               string
 	DomainReachabilityChecker entities.DomainReachabilityChecker
+	Hooks                     *entities.HookRegistry
+	ActorResolver             entities.ActorResolver
 }
 
 type ServeOutput struct {
@@ -28,6 +28,9 @@ type ServeOutputContent struct {
 	Env         *entities.Env
 	Port        string
 	PortBinding string
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The port is served in any case.
+	PostHooksErrors []error
 }
 
 type ServeOutputHandler interface {
@@ -111,104 +114,44 @@ func (s ServeFeature) Execute(input ServeInput) error {
 	}
 
 	clusterName := entities.DefaultClusterName
-	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
 
 	if err != nil {
 		return handleError(err)
 	}
 
-	envName := input.EnvName
-	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+	cluster, err := elevenConfig.GetCluster(clusterName)
 
 	if err != nil {
 		return handleError(err)
 	}
 
-	if env.Status == entities.EnvStatusRemoving {
-		return handleError(entities.ErrServeRemovingEnv{
-			EnvName: envName,
-		})
-	}
-
-	if env.Status == entities.EnvStatusCreating {
-		return handleError(entities.ErrServeCreatingEnv{
-			EnvName: envName,
-		})
-	}
-
-	portBinding := input.PortBinding
-	if len(portBinding) == 0 {
-		// if no binding was passed,
-		// the user wants port to be served
-		// using same port number
-		portBinding = input.Port
-	}
-
-	portBindingAlreadyUsed := env.DoesServedPortBindingExist(portBinding)
-
-	if !portBindingAlreadyUsed && govalidator.IsPort(portBinding) {
-		err = actions.OpenPort(
-			s.stepper,
-			cloudService,
-			elevenConfig,
-			cluster,
-			env,
-			portBinding,
-		)
-
-		if err != nil {
-			return handleError(err)
-		}
-	}
-
-	redirPortBindingHTTPS := false
-
-	if !govalidator.IsPort(portBinding) {
-		step := fmt.Sprintf(
-			"Checking that \"%s\" resolves to your sandbox's public IP address",
-			portBinding,
-		)
-
-		s.stepper.StartTemporaryStep(step)
-
-		reachable, redirToHTTPS, err := input.DomainReachabilityChecker.Check(
-			env,
-			portBinding,
-		)
-
-		if err != nil {
-			return handleError(err)
-		}
-
-		if !reachable {
-			return handleError(entities.ErrUnresolvableDomain{
-				Domain:       portBinding,
-				EnvIPAddress: env.InstancePublicIPAddress,
-			})
-		}
-
-		redirPortBindingHTTPS = redirToHTTPS
-	}
+	envName := input.EnvName
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
 
-	if portBindingAlreadyUsed {
-		env.RemoveServedPortBinding(
-			entities.EnvServedPort(input.Port),
-			portBinding,
-		)
+	if err != nil {
+		return handleError(err)
 	}
 
-	env.AddServedPortBinding(
-		entities.EnvServedPort(input.Port),
-		portBinding,
-		redirPortBindingHTTPS,
-	)
+	postHooksErrs := newPostHooksErrors()
 
-	err = actions.UpdateEnvInConfig(
+	portBinding, err := servePortWithHooks(
 		s.stepper,
 		cloudService,
 		elevenConfig,
 		cluster,
 		env,
+		input.Port,
+		input.PortBinding,
+		input.DomainReachabilityChecker,
+		input.Hooks,
+		postHooksErrs,
 	)
 
 	if err != nil {
@@ -218,10 +161,11 @@ func (s ServeFeature) Execute(input ServeInput) error {
 	return s.outputHandler.HandleOutput(ServeOutput{
 		Stepper: s.stepper,
 		Content: &ServeOutputContent{
-			Cluster:     cluster,
-			Env:         env,
-			Port:        input.Port,
-			PortBinding: portBinding,
+			Cluster:         cluster,
+			Env:             env,
+			Port:            input.Port,
+			PortBinding:     portBinding,
+			PostHooksErrors: postHooksErrs.get(),
 		},
 	})
 }
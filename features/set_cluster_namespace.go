@@ -0,0 +1,147 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetClusterNamespaceInput struct {
+	// ClusterName defaults to the default cluster
+	ClusterName   string
+	Namespace     string
+	ActorResolver entities.ActorResolver
+}
+
+type SetClusterNamespaceOutput struct {
+	Error   error
+	Content *SetClusterNamespaceOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetClusterNamespaceOutputContent struct {
+	Cluster           *entities.Cluster
+	Namespace         *entities.Namespace
+	PreviousNamespace string
+}
+
+type SetClusterNamespaceOutputHandler interface {
+	HandleOutput(SetClusterNamespaceOutput) error
+}
+
+type SetClusterNamespaceFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetClusterNamespaceOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetClusterNamespaceFeature(
+	stepper stepper.Stepper,
+	outputHandler SetClusterNamespaceOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetClusterNamespaceFeature {
+
+	return SetClusterNamespaceFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetClusterNamespaceFeature) Execute(input SetClusterNamespaceInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetClusterNamespaceOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	clusterName := input.ClusterName
+
+	if len(clusterName) == 0 {
+		clusterName = entities.DefaultClusterName
+	}
+
+	s.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Moving the cluster \"%s\" to the namespace \"%s\"",
+			clusterName,
+			input.Namespace,
+		),
+	)
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	namespace, err := elevenConfig.GetNamespace(input.Namespace)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = actions.CheckClusterNamespaceQuotas(
+		s.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		namespace,
+		time.Now(),
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	previousNamespace := cluster.Namespace
+	cluster.Namespace = namespace.Name
+
+	err = cloudService.SaveElevenConfig(
+		s.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetClusterNamespaceOutput{
+		Stepper: s.stepper,
+		Content: &SetClusterNamespaceOutputContent{
+			Cluster:           cluster,
+			Namespace:         namespace,
+			PreviousNamespace: previousNamespace,
+		},
+	})
+}
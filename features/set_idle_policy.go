@@ -0,0 +1,175 @@
+package features
+
+import (
+	"time"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetIdlePolicyInput struct {
+	// EnvName is empty when the policy
+	// is set on the default cluster
+	EnvName string
+	// A zero idle timeout removes the policy
+	// so that the cluster one applies to the env
+	IdleTimeout time.Duration
+	// Disable sets a disabled policy to let
+	// the env opt out of the cluster policy
+	Disable       bool
+	ActorResolver entities.ActorResolver
+}
+
+type SetIdlePolicyOutput struct {
+	Error   error
+	Content *SetIdlePolicyOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetIdlePolicyOutputContent struct {
+	Cluster    *entities.Cluster
+	Env        *entities.Env
+	IdlePolicy *entities.IdlePolicy
+}
+
+type SetIdlePolicyOutputHandler interface {
+	HandleOutput(SetIdlePolicyOutput) error
+}
+
+type SetIdlePolicyFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetIdlePolicyOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetIdlePolicyFeature(
+	stepper stepper.Stepper,
+	outputHandler SetIdlePolicyOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetIdlePolicyFeature {
+
+	return SetIdlePolicyFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetIdlePolicyFeature) Execute(input SetIdlePolicyInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetIdlePolicyOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep("Setting the idle policy")
+
+	var idlePolicy *entities.IdlePolicy
+
+	if input.Disable {
+		idlePolicy = entities.NewDisabledIdlePolicy()
+	} else if input.IdleTimeout != 0 {
+		policy, err := entities.NewIdlePolicy(input.IdleTimeout)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		idlePolicy = policy
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if len(input.EnvName) == 0 {
+		cluster.IdlePolicy = idlePolicy
+
+		err = actions.UpdateClusterInConfig(
+			s.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		return s.outputHandler.HandleOutput(SetIdlePolicyOutput{
+			Stepper: s.stepper,
+			Content: &SetIdlePolicyOutputContent{
+				Cluster:    cluster,
+				IdlePolicy: idlePolicy,
+			},
+		})
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, input.EnvName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrIdlePolicyRemovingEnv{
+			EnvName: env.Name,
+		})
+	}
+
+	env.IdlePolicy = idlePolicy
+
+	err = actions.UpdateEnvInConfig(
+		s.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetIdlePolicyOutput{
+		Stepper: s.stepper,
+		Content: &SetIdlePolicyOutputContent{
+			Cluster:    cluster,
+			Env:        env,
+			IdlePolicy: idlePolicy,
+		},
+	})
+}
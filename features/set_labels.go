@@ -0,0 +1,125 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetLabelsInput struct {
+	// EnvName is empty when labels
+	// are set on the default cluster
+	EnvName       string
+	Labels        entities.Labels
+	ActorResolver entities.ActorResolver
+}
+
+type SetLabelsOutput struct {
+	Error   error
+	Content *SetLabelsOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetLabelsOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	Labels  entities.Labels
+}
+
+type SetLabelsOutputHandler interface {
+	HandleOutput(SetLabelsOutput) error
+}
+
+type SetLabelsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetLabelsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetLabelsFeature(
+	stepper stepper.Stepper,
+	outputHandler SetLabelsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetLabelsFeature {
+
+	return SetLabelsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetLabelsFeature) Execute(input SetLabelsInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetLabelsOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep("Setting labels")
+
+	err := entities.CheckLabelsValidity(input.Labels)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, env, err := updateLabels(
+		s.stepper,
+		cloudService,
+		elevenConfig,
+		input.EnvName,
+		func(env *entities.Env) error {
+			return env.SetLabels(input.Labels)
+		},
+		func(cluster *entities.Cluster) error {
+			return cluster.SetLabels(input.Labels)
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	labels := cluster.Labels
+
+	if env != nil {
+		labels = env.Labels
+	}
+
+	return s.outputHandler.HandleOutput(SetLabelsOutput{
+		Stepper: s.stepper,
+		Content: &SetLabelsOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Labels:  labels,
+		},
+	})
+}
@@ -0,0 +1,160 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetMemberInput struct {
+	Username string
+	Role     string
+	// Namespace is empty when the member
+	// is set for the whole installation
+	Namespace     string
+	ActorResolver entities.ActorResolver
+}
+
+type SetMemberOutput struct {
+	Error   error
+	Content *SetMemberOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetMemberOutputContent struct {
+	Member  *entities.ConfigMember
+	Members []*entities.ConfigMember
+}
+
+type SetMemberOutputHandler interface {
+	HandleOutput(SetMemberOutput) error
+}
+
+type SetMemberFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetMemberOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetMemberFeature(
+	stepper stepper.Stepper,
+	outputHandler SetMemberOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetMemberFeature {
+
+	return SetMemberFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetMemberFeature) Execute(input SetMemberInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetMemberOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Setting the role of \"%s\" to \"%s\"",
+			input.Username,
+			input.Role,
+		),
+	)
+
+	err := entities.CheckGitHubUsernameValidity(input.Username)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	role, err := entities.ParseMemberRole(input.Role)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Namespace admins manage the members of their namespace
+	err = checkNamespacePermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+		input.Namespace,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	member := entities.NewConfigMember(input.Username, role)
+	var members entities.ConfigMembers
+
+	// The first member must be an admin so that
+	// the installation (or namespace) stays manageable
+	if len(input.Namespace) > 0 {
+		namespace, err := elevenConfig.GetNamespace(input.Namespace)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		// Namespace members share the installation so an
+		// installation admin is required to manage it
+		if !elevenConfig.HasMembers() {
+			return handleError(entities.ErrNoConfigAdmin)
+		}
+
+		err = namespace.SetMember(member)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		members = namespace.Members
+	} else {
+		err = elevenConfig.SetMember(member)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		members = elevenConfig.Members
+	}
+
+	err = cloudService.SaveElevenConfig(
+		s.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetMemberOutput{
+		Stepper: s.stepper,
+		Content: &SetMemberOutputContent{
+			Member:  member,
+			Members: members.GetSorted(),
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetNamespaceInput struct {
+	Name string
+	// Quotas are left untouched when nil
+	Quotas        *entities.ConfigQuotas
+	ActorResolver entities.ActorResolver
+}
+
+type SetNamespaceOutput struct {
+	Error   error
+	Content *SetNamespaceOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetNamespaceOutputContent struct {
+	Namespace        *entities.Namespace
+	NamespaceCreated bool
+}
+
+type SetNamespaceOutputHandler interface {
+	HandleOutput(SetNamespaceOutput) error
+}
+
+type SetNamespaceFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetNamespaceOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetNamespaceFeature(
+	stepper stepper.Stepper,
+	outputHandler SetNamespaceOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetNamespaceFeature {
+
+	return SetNamespaceFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetNamespaceFeature) Execute(input SetNamespaceInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetNamespaceOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep(
+		fmt.Sprintf("Setting the namespace \"%s\"", input.Name),
+	)
+
+	err := entities.CheckNamespaceNameValidity(input.Name)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var quotas *entities.ConfigQuotas
+
+	if input.Quotas != nil {
+		quotasCopy := *input.Quotas
+
+		if quotasCopy.AllowedInstanceTypes == nil {
+			quotasCopy.AllowedInstanceTypes = []string{}
+		}
+
+		err = entities.CheckConfigQuotasValidity(quotasCopy)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		quotas = &quotasCopy
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if quotas != nil {
+		for _, instanceType := range quotas.AllowedInstanceTypes {
+			err = cloudService.CheckInstanceTypeValidity(
+				s.stepper,
+				instanceType,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+		}
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	namespaceCreated := !elevenConfig.NamespaceExists(input.Name)
+
+	if namespaceCreated {
+		elevenConfig.SetNamespace(entities.NewNamespace(input.Name))
+	}
+
+	namespace, err := elevenConfig.GetNamespace(input.Name)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if quotas != nil {
+		namespace.Quotas = *quotas
+	}
+
+	err = cloudService.SaveElevenConfig(
+		s.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetNamespaceOutput{
+		Stepper: s.stepper,
+		Content: &SetNamespaceOutputContent{
+			Namespace:        namespace,
+			NamespaceCreated: namespaceCreated,
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetQuotasInput struct {
+	Quotas        entities.ConfigQuotas
+	ActorResolver entities.ActorResolver
+}
+
+type SetQuotasOutput struct {
+	Error   error
+	Content *SetQuotasOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetQuotasOutputContent struct {
+	Quotas entities.ConfigQuotas
+}
+
+type SetQuotasOutputHandler interface {
+	HandleOutput(SetQuotasOutput) error
+}
+
+type SetQuotasFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetQuotasOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetQuotasFeature(
+	stepper stepper.Stepper,
+	outputHandler SetQuotasOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetQuotasFeature {
+
+	return SetQuotasFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetQuotasFeature) Execute(input SetQuotasInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetQuotasOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep("Setting the quotas")
+
+	quotas := input.Quotas
+
+	if quotas.AllowedInstanceTypes == nil {
+		quotas.AllowedInstanceTypes = []string{}
+	}
+
+	err := entities.CheckConfigQuotasValidity(quotas)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	for _, instanceType := range quotas.AllowedInstanceTypes {
+		err = cloudService.CheckInstanceTypeValidity(
+			s.stepper,
+			instanceType,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig.Quotas = quotas
+
+	err = cloudService.SaveElevenConfig(
+		s.stepper,
+		elevenConfig,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetQuotasOutput{
+		Stepper: s.stepper,
+		Content: &SetQuotasOutputContent{
+			Quotas: quotas,
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SetSecretInput struct {
+	EnvName       string
+	SecretName    string
+	SecretValue   string `audit:"-"`
+	SecretScope   string
+	ActorResolver entities.ActorResolver
+}
+
+type SetSecretOutput struct {
+	Error   error
+	Content *SetSecretOutputContent
+	Stepper stepper.Stepper
+}
+
+type SetSecretOutputContent struct {
+	Cluster      *entities.Cluster
+	Env          *entities.Env
+	SecretName   string
+	SecretsFiles []entities.EnvSecretsFile
+}
+
+type SetSecretOutputHandler interface {
+	HandleOutput(SetSecretOutput) error
+}
+
+type SetSecretFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SetSecretOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSetSecretFeature(
+	stepper stepper.Stepper,
+	outputHandler SetSecretOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SetSecretFeature {
+
+	return SetSecretFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SetSecretFeature) Execute(input SetSecretInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SetSecretOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	s.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Setting the secret \"%s\" in the sandbox \"%s\"",
+			input.SecretName,
+			envName,
+		),
+	)
+
+	err := entities.CheckEnvSecretNameValidity(input.SecretName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrSecretsRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrSecretsCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	secretScope, err := env.ParseSecretScope(input.SecretScope)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = env.SetSecret(
+		input.SecretName,
+		input.SecretValue,
+		secretScope,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = actions.UpdateEnvInConfig(
+		s.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SetSecretOutput{
+		Stepper: s.stepper,
+		Content: &SetSecretOutputContent{
+			Cluster:      cluster,
+			Env:          env,
+			SecretName:   input.SecretName,
+			SecretsFiles: env.BuildSecretsFiles(),
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type SnapshotInput struct {
+	EnvName       string
+	SnapshotName  string
+	ActorResolver entities.ActorResolver
+}
+
+type SnapshotOutput struct {
+	Error   error
+	Content *SnapshotOutputContent
+	Stepper stepper.Stepper
+}
+
+type SnapshotOutputContent struct {
+	Cluster  *entities.Cluster
+	Env      *entities.Env
+	Snapshot *entities.Snapshot
+}
+
+type SnapshotOutputHandler interface {
+	HandleOutput(SnapshotOutput) error
+}
+
+type SnapshotFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       SnapshotOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewSnapshotFeature(
+	stepper stepper.Stepper,
+	outputHandler SnapshotOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) SnapshotFeature {
+
+	return SnapshotFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s SnapshotFeature) Execute(input SnapshotInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(SnapshotOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	s.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Creating the snapshot \"%s\" of the sandbox \"%s\"",
+			input.SnapshotName,
+			envName,
+		),
+	)
+
+	err := entities.CheckSnapshotNameValidity(input.SnapshotName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrSnapshotRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrSnapshotCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	snapshot, err := cluster.GetSnapshot(input.SnapshotName)
+
+	if err != nil && !errors.As(err, &entities.ErrSnapshotNotExists{}) {
+		return handleError(err)
+	}
+
+	// Snapshots still in creating state
+	// after error could be created again
+	if snapshot != nil && snapshot.Status != entities.SnapshotStatusCreating {
+		return handleError(entities.ErrSnapshotAlreadyExists{
+			ClusterName:  cluster.Name,
+			SnapshotName: snapshot.Name,
+		})
+	}
+
+	if snapshot == nil {
+		snapshot = entities.NewSnapshot(input.SnapshotName, env)
+	}
+
+	err = actions.CreateSnapshot(
+		s.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		snapshot,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return s.outputHandler.HandleOutput(SnapshotOutput{
+		Stepper: s.stepper,
+		Content: &SnapshotOutputContent{
+			Cluster:  cluster,
+			Env:      env,
+			Snapshot: snapshot,
+		},
+	})
+}
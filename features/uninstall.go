@@ -11,6 +11,17 @@ import (
 type UninstallInput struct {
 	SuccessMessage            string
 	AlreadyUninstalledMessage string
+	// Cascade removes all the envs of all the
+	// clusters instead of refusing to uninstall
+	Cascade                bool
+	MaxConcurrentRemovals  int
+	PreRemoveHook          entities.HookRunner
+	ForceUninstall         bool
+	ConfirmCascadeRemovals func([]*entities.Cluster) (bool, error)
+	// Hooks run concurrently for the envs
+	// removed during a cascade uninstall
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
 }
 
 type UninstallOutput struct {
@@ -23,6 +34,22 @@ type UninstallOutputContent struct {
 	ElevenAlreadyUninstalled  bool
 	SuccessMessage            string
 	AlreadyUninstalledMessage string
+	Report                    *UninstallReport
+	// PostHooksErrors lists the errors returned by post-hooks.
+	// The resources are removed in any case.
+	PostHooksErrors []error
+}
+
+// UninstallReport describes the removal
+// of each resource during a cascade uninstall
+type UninstallReport struct {
+	Envs     []actions.EnvOperationResult
+	Clusters []UninstallReportCluster
+}
+
+type UninstallReportCluster struct {
+	ClusterName string
+	Error       error
 }
 
 type UninstallOutputHandler interface {
@@ -85,6 +112,37 @@ func (u UninstallFeature) Execute(input UninstallInput) error {
 		return handleError(err)
 	}
 
+	err = checkPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageInstallation,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = input.Hooks.Run(entities.HookPayload{
+		Event:        entities.HookEventPreUninstall,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+	})
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	postHooksErrs := newPostHooksErrors()
+
+	if input.Cascade {
+		return u.executeCascade(
+			input,
+			cloudService,
+			elevenConfig,
+			postHooksErrs,
+		)
+	}
+
 	clusterName := entities.DefaultClusterName
 	cluster, err := elevenConfig.GetCluster(clusterName)
 
@@ -105,13 +163,180 @@ func (u UninstallFeature) Execute(input UninstallInput) error {
 			return handleError(entities.ErrUninstallExistingEnvs)
 		}
 
-		err = actions.RemoveCluster(
+		err = removeClusterWithHooks(
+			u.stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			input.Hooks,
+			postHooksErrs,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	err = cloudService.RemoveElevenConfigStorage(
+		u.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	postHooksErrs.run(input.Hooks, entities.HookPayload{
+		Event:        entities.HookEventPostUninstall,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+	})
+
+	return u.outputHandler.HandleOutput(UninstallOutput{
+		Stepper: u.stepper,
+		Content: &UninstallOutputContent{
+			ElevenAlreadyUninstalled:  false,
+			SuccessMessage:            input.SuccessMessage,
+			AlreadyUninstalledMessage: input.AlreadyUninstalledMessage,
+			PostHooksErrors:           postHooksErrs.get(),
+		},
+	})
+}
+
+func (u UninstallFeature) executeCascade(
+	input UninstallInput,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	postHooksErrs *postHooksErrors,
+) error {
+
+	report := &UninstallReport{
+		Envs:     []actions.EnvOperationResult{},
+		Clusters: []UninstallReportCluster{},
+	}
+
+	handleError := func(err error) error {
+		u.outputHandler.HandleOutput(UninstallOutput{
+			Stepper: u.stepper,
+			Error:   err,
+			Content: &UninstallOutputContent{
+				SuccessMessage:            input.SuccessMessage,
+				AlreadyUninstalledMessage: input.AlreadyUninstalledMessage,
+				Report:                    report,
+				PostHooksErrors:           postHooksErrs.get(),
+			},
+		})
+
+		return err
+	}
+
+	clusters := elevenConfig.GetSortedClusters()
+	envTargets := []actions.EnvTarget{}
+
+	for _, cluster := range clusters {
+		for _, env := range cluster.GetSortedEnvs() {
+			envTargets = append(envTargets, actions.EnvTarget{
+				Cluster: cluster,
+				Env:     env,
+			})
+		}
+	}
+
+	if len(envTargets) > 0 &&
+		!input.ForceUninstall &&
+		input.ConfirmCascadeRemovals != nil {
+
+		u.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmCascadeRemovals(clusters)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		if !confirmed {
+			return nil
+		}
+
+		u.stepper.StartTemporaryStep("Uninstalling Eleven")
+	}
+
+	// Config writes are not batched to keep track
+	// of the removals that could fail midway
+	envResults, err := actions.RunEnvOperationsInParallel(
+		u.stepper,
+		cloudService,
+		elevenConfig,
+		envTargets,
+		actions.EnvOperationsOptions{
+			MaxConcurrency: input.MaxConcurrentRemovals,
+		},
+		func(
+			stepper stepper.Stepper,
+			cloudService entities.CloudService,
+			elevenConfig *entities.Config,
+			cluster *entities.Cluster,
+			env *entities.Env,
+		) error {
+
+			return removeEnvWithHooks(
+				stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				env,
+				input.PreRemoveHook,
+				input.Hooks,
+				postHooksErrs,
+			)
+		},
+	)
+
+	report.Envs = envResults
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	nbOfFailedRemovals := 0
+
+	for _, envResult := range report.Envs {
+		if envResult.Error != nil {
+			nbOfFailedRemovals++
+		}
+	}
+
+	// Clusters and config storage could not be
+	// removed while some of their envs remain
+	if nbOfFailedRemovals > 0 {
+		return handleError(entities.ErrUninstallRemovingEnvs{
+			NbOfFailedRemovals: nbOfFailedRemovals,
+		})
+	}
+
+	for _, cluster := range elevenConfig.GetSortedClusters() {
+		err := removeClusterSnapshots(
 			u.stepper,
 			cloudService,
 			elevenConfig,
 			cluster,
 		)
 
+		if err == nil {
+			err = removeClusterWithHooks(
+				u.stepper,
+				cloudService,
+				elevenConfig,
+				cluster,
+				input.Hooks,
+				postHooksErrs,
+			)
+		}
+
+		report.Clusters = append(report.Clusters, UninstallReportCluster{
+			ClusterName: cluster.Name,
+			Error:       err,
+		})
+
 		if err != nil {
 			return handleError(err)
 		}
@@ -125,12 +350,46 @@ func (u UninstallFeature) Execute(input UninstallInput) error {
 		return handleError(err)
 	}
 
+	postHooksErrs.run(input.Hooks, entities.HookPayload{
+		Event:        entities.HookEventPostUninstall,
+		CloudService: cloudService,
+		Config:       elevenConfig,
+	})
+
 	return u.outputHandler.HandleOutput(UninstallOutput{
 		Stepper: u.stepper,
 		Content: &UninstallOutputContent{
 			ElevenAlreadyUninstalled:  false,
 			SuccessMessage:            input.SuccessMessage,
 			AlreadyUninstalledMessage: input.AlreadyUninstalledMessage,
+			Report:                    report,
+			PostHooksErrors:           postHooksErrs.get(),
 		},
 	})
 }
+
+// removeClusterSnapshots prevents snapshots
+// from being orphaned when clusters are removed
+func removeClusterSnapshots(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	elevenConfig *entities.Config,
+	cluster *entities.Cluster,
+) error {
+
+	for _, snapshot := range cluster.GetSortedSnapshots() {
+		err := actions.RemoveSnapshot(
+			stepper,
+			cloudService,
+			elevenConfig,
+			cluster,
+			snapshot,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package features
 import (
 	"fmt"
 
-	"github.com/eleven-sh/eleven/actions"
 	"github.com/eleven-sh/eleven/entities"
 	"github.com/eleven-sh/eleven/stepper"
 )
@@ -12,6 +11,8 @@ type UnserveInput struct {
 	EnvName       string
 	ReservedPorts []string
 	Port          string
+	Hooks         *entities.HookRegistry
+	ActorResolver entities.ActorResolver
 }
 
 type UnserveOutput struct {
@@ -24,6 +25,9 @@ type UnserveOutputContent struct {
 	Cluster *entities.Cluster
 	Env     *entities.Env
 	Port    string
+	// PostHooksErrors lists the errors returned by
+	// post-hooks. The port is unserved in any case.
+	PostHooksErrors []error
 }
 
 type UnserveOutputHandler interface {
@@ -90,6 +94,18 @@ func (u UnserveFeature) Execute(input UnserveInput) error {
 	}
 
 	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	cluster, err := elevenConfig.GetCluster(clusterName)
 
 	if err != nil {
@@ -103,80 +119,30 @@ func (u UnserveFeature) Execute(input UnserveInput) error {
 		return handleError(err)
 	}
 
-	if env.Status == entities.EnvStatusRemoving {
-		return handleError(entities.ErrUnserveRemovingEnv{
-			EnvName: envName,
-		})
-	}
-
-	if env.Status == entities.EnvStatusCreating {
-		return handleError(entities.ErrUnserveCreatingEnv{
-			EnvName: envName,
-		})
-	}
+	postHooksErrs := newPostHooksErrors()
 
-	portAlreadyUnserved := !env.DoesServedPortExist(
-		entities.EnvServedPort(input.Port),
+	err = unservePortWithHooks(
+		u.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+		input.Port,
+		input.Hooks,
+		postHooksErrs,
 	)
 
-	if !portAlreadyUnserved {
-		for _, binding := range env.ServedPorts[entities.EnvServedPort(input.Port)] {
-			if binding.Type != entities.EnvServedPortBindingTypePort {
-				continue
-			}
-
-			err = actions.ClosePort(
-				u.stepper,
-				cloudService,
-				elevenConfig,
-				cluster,
-				env,
-				binding.Value,
-			)
-
-			if err != nil {
-				return handleError(err)
-			}
-
-			env.RemoveServedPortBinding(
-				entities.EnvServedPort(input.Port),
-				binding.Value,
-			)
-
-			err := actions.UpdateEnvInConfig(
-				u.stepper,
-				cloudService,
-				elevenConfig,
-				cluster,
-				env,
-			)
-
-			if err != nil {
-				return handleError(err)
-			}
-		}
-
-		env.RemoveServedPort(entities.EnvServedPort(input.Port))
-
-		err := actions.UpdateEnvInConfig(
-			u.stepper,
-			cloudService,
-			elevenConfig,
-			cluster,
-			env,
-		)
-
-		if err != nil {
-			return handleError(err)
-		}
+	if err != nil {
+		return handleError(err)
 	}
 
 	return u.outputHandler.HandleOutput(UnserveOutput{
 		Stepper: u.stepper,
 		Content: &UnserveOutputContent{
-			Cluster: cluster,
-			Env:     env,
-			Port:    input.Port,
+			Cluster:         cluster,
+			Env:             env,
+			Port:            input.Port,
+			PostHooksErrors: postHooksErrs.get(),
 		},
 	})
 }
@@ -0,0 +1,121 @@
+package features
+
+import (
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type UnsetLabelsInput struct {
+	// EnvName is empty when labels are
+	// unset from the default cluster
+	EnvName       string
+	LabelKeys     []string
+	ActorResolver entities.ActorResolver
+}
+
+type UnsetLabelsOutput struct {
+	Error   error
+	Content *UnsetLabelsOutputContent
+	Stepper stepper.Stepper
+}
+
+type UnsetLabelsOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	Labels  entities.Labels
+}
+
+type UnsetLabelsOutputHandler interface {
+	HandleOutput(UnsetLabelsOutput) error
+}
+
+type UnsetLabelsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       UnsetLabelsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewUnsetLabelsFeature(
+	stepper stepper.Stepper,
+	outputHandler UnsetLabelsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) UnsetLabelsFeature {
+
+	return UnsetLabelsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (u UnsetLabelsFeature) Execute(input UnsetLabelsInput) error {
+	handleError := func(err error) error {
+		u.outputHandler.HandleOutput(UnsetLabelsOutput{
+			Stepper: u.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	u.stepper.StartTemporaryStep("Unsetting labels")
+
+	cloudService, err := u.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		u.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		entities.DefaultClusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, env, err := updateLabels(
+		u.stepper,
+		cloudService,
+		elevenConfig,
+		input.EnvName,
+		func(env *entities.Env) error {
+			env.UnsetLabels(input.LabelKeys)
+			return nil
+		},
+		func(cluster *entities.Cluster) error {
+			cluster.UnsetLabels(input.LabelKeys)
+			return nil
+		},
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	labels := cluster.Labels
+
+	if env != nil {
+		labels = env.Labels
+	}
+
+	return u.outputHandler.HandleOutput(UnsetLabelsOutput{
+		Stepper: u.stepper,
+		Content: &UnsetLabelsOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Labels:  labels,
+		},
+	})
+}
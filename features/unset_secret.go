@@ -0,0 +1,151 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/eleven-sh/eleven/actions"
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/eleven-sh/eleven/stepper"
+)
+
+type UnsetSecretInput struct {
+	EnvName       string
+	SecretName    string
+	ActorResolver entities.ActorResolver
+}
+
+type UnsetSecretOutput struct {
+	Error   error
+	Content *UnsetSecretOutputContent
+	Stepper stepper.Stepper
+}
+
+type UnsetSecretOutputContent struct {
+	Cluster      *entities.Cluster
+	Env          *entities.Env
+	SecretName   string
+	SecretsFiles []entities.EnvSecretsFile
+}
+
+type UnsetSecretOutputHandler interface {
+	HandleOutput(UnsetSecretOutput) error
+}
+
+type UnsetSecretFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       UnsetSecretOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewUnsetSecretFeature(
+	stepper stepper.Stepper,
+	outputHandler UnsetSecretOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) UnsetSecretFeature {
+
+	return UnsetSecretFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (u UnsetSecretFeature) Execute(input UnsetSecretInput) error {
+	handleError := func(err error) error {
+		u.outputHandler.HandleOutput(UnsetSecretOutput{
+			Stepper: u.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	envName := input.EnvName
+
+	u.stepper.StartTemporaryStep(
+		fmt.Sprintf(
+			"Unsetting the secret \"%s\" in the sandbox \"%s\"",
+			input.SecretName,
+			envName,
+		),
+	)
+
+	cloudService, err := u.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	elevenConfig, err := cloudService.LookupElevenConfig(
+		u.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+
+	err = checkClusterPermission(
+		elevenConfig,
+		input.ActorResolver,
+		entities.PermissionManageEnvs,
+		clusterName,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	cluster, err := elevenConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := elevenConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrSecretsRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrSecretsCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	err = env.UnsetSecret(input.SecretName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	err = actions.UpdateEnvInConfig(
+		u.stepper,
+		cloudService,
+		elevenConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return u.outputHandler.HandleOutput(UnsetSecretOutput{
+		Stepper: u.stepper,
+		Content: &UnsetSecretOutputContent{
+			Cluster:      cluster,
+			Env:          env,
+			SecretName:   input.SecretName,
+			SecretsFiles: env.BuildSecretsFiles(),
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package github
+
+import "sync"
+
+// ActorResolver resolves the actor of the features
+// as the GitHub user (or app's bot user) that owns
+// the service's credentials.
+type ActorResolver struct {
+	service Service
+
+	mutex    *sync.Mutex
+	username string
+}
+
+func NewActorResolver(service Service) *ActorResolver {
+	return &ActorResolver{
+		service: service,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+func (a *ActorResolver) ResolveActor() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.username) > 0 {
+		return a.username, nil
+	}
+
+	username, err := a.service.GetAuthenticatedLogin()
+
+	if err != nil {
+		return "", err
+	}
+
+	a.username = username
+
+	return a.username, nil
+}
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Credentials represents anything that could be
+// exchanged for an access token usable with
+// the service methods.
+type Credentials interface {
+	AccessToken() (string, error)
+}
+
+type AccessTokenType string
+
+const (
+	AccessTokenTypeUnknown             AccessTokenType = "unknown"
+	AccessTokenTypeOAuth               AccessTokenType = "oauth"
+	AccessTokenTypeClassicPersonal     AccessTokenType = "classic_personal"
+	AccessTokenTypeFineGrainedPersonal AccessTokenType = "fine_grained_personal"
+	AccessTokenTypeAppInstallation     AccessTokenType = "app_installation"
+	AccessTokenTypeAppUser             AccessTokenType = "app_user"
+)
+
+// See https://github.blog/2021-04-05-behind-githubs-new-authentication-token-formats/
+var accessTokenTypesByPrefix = map[string]AccessTokenType{
+	"gho_":        AccessTokenTypeOAuth,
+	"ghp_":        AccessTokenTypeClassicPersonal,
+	"github_pat_": AccessTokenTypeFineGrainedPersonal,
+	"ghs_":        AccessTokenTypeAppInstallation,
+	"ghu_":        AccessTokenTypeAppUser,
+}
+
+func DetectAccessTokenType(accessToken string) AccessTokenType {
+	for prefix, accessTokenType := range accessTokenTypesByPrefix {
+		if strings.HasPrefix(accessToken, prefix) {
+			return accessTokenType
+		}
+	}
+
+	return AccessTokenTypeUnknown
+}
+
+type ErrAccessTokenExpired struct {
+	ExpiredAt time.Time
+}
+
+func (ErrAccessTokenExpired) Error() string {
+	return "ErrAccessTokenExpired"
+}
+
+// OAuthCredentials represents a classic OAuth access token
+// (or a classic personal access token) that never expires.
+type OAuthCredentials struct {
+	accessToken string
+}
+
+func NewOAuthCredentials(accessToken string) OAuthCredentials {
+	return OAuthCredentials{
+		accessToken: accessToken,
+	}
+}
+
+func (o OAuthCredentials) AccessToken() (string, error) {
+	return o.accessToken, nil
+}
+
+// FineGrainedTokenCredentials represents a fine-grained
+// personal access token. A zero expiration time means
+// that the token never expires.
+type FineGrainedTokenCredentials struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewFineGrainedTokenCredentials(
+	accessToken string,
+	expiresAt time.Time,
+) FineGrainedTokenCredentials {
+
+	return FineGrainedTokenCredentials{
+		accessToken: accessToken,
+		expiresAt:   expiresAt,
+	}
+}
+
+func (f FineGrainedTokenCredentials) AccessToken() (string, error) {
+	if f.IsExpired() {
+		return "", ErrAccessTokenExpired{
+			ExpiredAt: f.expiresAt,
+		}
+	}
+
+	return f.accessToken, nil
+}
+
+func (f FineGrainedTokenCredentials) IsExpired() bool {
+	return !f.expiresAt.IsZero() && !time.Now().Before(f.expiresAt)
+}
+
+const accessTokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+
+var accessTokenExpirationLayouts = []string{
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+// GetAccessTokenExpiration returns the expiration time
+// of the service's access token as reported by GitHub.
+// A nil time is returned for tokens that never expire.
+func (s Service) GetAccessTokenExpiration() (*time.Time, error) {
+	client := s.buildClient()
+
+	// Passing the empty string will
+	// fetch the authenticated user
+	userName := ""
+	_, resp, err := client.Users.Get(context.TODO(), userName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAccessTokenExpiration(
+		resp.Header.Get(accessTokenExpirationHeader),
+	)
+}
+
+func parseAccessTokenExpiration(expiration string) (*time.Time, error) {
+	if len(expiration) == 0 {
+		return nil, nil
+	}
+
+	var parseErr error
+
+	for _, layout := range accessTokenExpirationLayouts {
+		expiresAt, err := time.Parse(layout, expiration)
+
+		if err == nil {
+			return &expiresAt, nil
+		}
+
+		parseErr = err
+	}
+
+	return nil, parseErr
+}
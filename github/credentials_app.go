@@ -0,0 +1,232 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v43/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitHub refuses JWTs that expire more than ten minutes in the future
+	appJWTLifetime = 9 * time.Minute
+	// Protects against clock drift between us and GitHub
+	appJWTClockDrift = 60 * time.Second
+	// Installation tokens are refreshed a bit
+	// before their expiration to avoid failing requests
+	appInstallationTokenRefreshMargin = 5 * time.Minute
+)
+
+// AppInstallationCredentials exchanges a JWT signed with
+// the private key of a GitHub App for an installation
+// access token. Tokens are cached and refreshed on demand.
+type AppInstallationCredentials struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+
+	baseURL *url.URL
+	now     func() time.Time
+
+	mutex     *sync.Mutex
+	token     string
+	expiresAt time.Time
+	botLogin  string
+}
+
+func NewAppInstallationCredentials(
+	appID int64,
+	installationID int64,
+	privateKeyPEMContent []byte,
+	opts ...Option,
+) (*AppInstallationCredentials, error) {
+
+	privateKey, err := parseAppPrivateKey(privateKeyPEMContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	builtOptions := buildOptions(opts)
+
+	return &AppInstallationCredentials{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+		baseURL:        builtOptions.baseURL,
+		now:            time.Now,
+		mutex:          &sync.Mutex{},
+	}, nil
+}
+
+func (a *AppInstallationCredentials) AccessToken() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.token) > 0 &&
+		a.now().Add(appInstallationTokenRefreshMargin).Before(a.expiresAt) {
+
+		return a.token, nil
+	}
+
+	client, err := a.buildAppClient()
+
+	if err != nil {
+		return "", err
+	}
+
+	installationToken, _, err := client.Apps.CreateInstallationToken(
+		context.TODO(),
+		a.installationID,
+		nil,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	a.token = installationToken.GetToken()
+	a.expiresAt = installationToken.GetExpiresAt()
+
+	return a.token, nil
+}
+
+// BotLogin returns the login of the bot user that GitHub
+// associates with the actions performed using the installation
+// tokens (e.g. "my-app[bot]"). Installation tokens cannot
+// fetch the authenticated user so the app is fetched instead.
+func (a *AppInstallationCredentials) BotLogin() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.botLogin) > 0 {
+		return a.botLogin, nil
+	}
+
+	client, err := a.buildAppClient()
+
+	if err != nil {
+		return "", err
+	}
+
+	// Passing the empty string will
+	// fetch the authenticated app
+	appSlug := ""
+	app, _, err := client.Apps.Get(context.TODO(), appSlug)
+
+	if err != nil {
+		return "", err
+	}
+
+	a.botLogin = app.GetSlug() + "[bot]"
+
+	return a.botLogin, nil
+}
+
+// buildAppClient builds a client
+// authenticated as the app itself
+func (a *AppInstallationCredentials) buildAppClient() (*gogithub.Client, error) {
+	jwt, err := a.buildJWT()
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := gogithub.NewClient(oauth2.NewClient(
+		context.TODO(),
+		oauth2.StaticTokenSource(&oauth2.Token{
+			AccessToken: jwt,
+		}),
+	))
+
+	if a.baseURL != nil {
+		client.BaseURL = a.baseURL
+	}
+
+	return client, nil
+}
+
+func (a *AppInstallationCredentials) buildJWT() (string, error) {
+	now := a.now()
+
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	}
+
+	claims := map[string]interface{}{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": fmt.Sprintf("%d", a.appID),
+	}
+
+	headerJSON, err := json.Marshal(header)
+
+	if err != nil {
+		return "", err
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", err
+	}
+
+	unsignedJWT := base64.RawURLEncoding.EncodeToString(headerJSON) +
+		"." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	hashedJWT := sha256.Sum256([]byte(unsignedJWT))
+
+	signature, err := rsa.SignPKCS1v15(
+		rand.Reader,
+		a.privateKey,
+		crypto.SHA256,
+		hashedJWT[:],
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return unsignedJWT + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseAppPrivateKey(privateKeyPEMContent []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEMContent)
+
+	if block == nil {
+		return nil, ErrInvalidAppPrivateKey
+	}
+
+	// GitHub generates PKCS#1 keys but
+	// converted PKCS#8 keys are also handled
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, ErrInvalidAppPrivateKey
+	}
+
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, ErrInvalidAppPrivateKey
+	}
+
+	return privateKey, nil
+}
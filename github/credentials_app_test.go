@@ -0,0 +1,209 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateTestAppPrivateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	privateKeyPEMContent := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	return privateKey, privateKeyPEMContent
+}
+
+func TestNewAppInstallationCredentialsWithInvalidPrivateKey(t *testing.T) {
+	_, err := NewAppInstallationCredentials(
+		1,
+		2,
+		[]byte("invalid_private_key"),
+	)
+
+	if !errors.Is(err, ErrInvalidAppPrivateKey) {
+		t.Fatalf(
+			"expected error to equal '%+v', got '%+v'",
+			ErrInvalidAppPrivateKey,
+			err,
+		)
+	}
+}
+
+func TestAppInstallationCredentialsBuildJWT(t *testing.T) {
+	privateKey, privateKeyPEMContent := generateTestAppPrivateKey(t)
+
+	credentials, err := NewAppInstallationCredentials(
+		1234,
+		5678,
+		privateKeyPEMContent,
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	credentials.now = func() time.Time { return now }
+
+	jwt, err := credentials.buildJWT()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	jwtParts := strings.Split(jwt, ".")
+
+	if len(jwtParts) != 3 {
+		t.Fatalf("expected JWT to have 3 parts, got %d", len(jwtParts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(jwtParts[2])
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	hashedJWT := sha256.Sum256([]byte(jwtParts[0] + "." + jwtParts[1]))
+
+	err = rsa.VerifyPKCS1v15(
+		&privateKey.PublicKey,
+		crypto.SHA256,
+		hashedJWT[:],
+		signature,
+	)
+
+	if err != nil {
+		t.Fatalf("expected valid signature, got '%+v'", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(jwtParts[1])
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+
+	err = json.Unmarshal(claimsJSON, &claims)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if claims.Issuer != "1234" {
+		t.Fatalf("expected issuer to equal '1234', got '%s'", claims.Issuer)
+	}
+
+	if claims.IssuedAt != now.Add(-appJWTClockDrift).Unix() {
+		t.Fatalf("expected issued at to be backdated, got '%d'", claims.IssuedAt)
+	}
+
+	if claims.ExpiresAt != now.Add(appJWTLifetime).Unix() {
+		t.Fatalf("expected expires at to equal '%d', got '%d'", now.Add(appJWTLifetime).Unix(), claims.ExpiresAt)
+	}
+}
+
+func TestAppInstallationCredentialsAccessToken(t *testing.T) {
+	_, privateKeyPEMContent := generateTestAppPrivateKey(t)
+
+	now := time.Now()
+	nbOfExchanges := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost ||
+			r.URL.Path != "/app/installations/5678/access_tokens" {
+
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		nbOfExchanges++
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(
+			w,
+			`{"token": "ghs_token_%d", "expires_at": "%s"}`,
+			nbOfExchanges,
+			now.Add(time.Hour).UTC().Format(time.RFC3339),
+		)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	credentials, err := NewAppInstallationCredentials(
+		1234,
+		5678,
+		privateKeyPEMContent,
+		WithBaseURL(baseURL),
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+	credentials.now = func() time.Time { return now }
+
+	accessToken, err := credentials.AccessToken()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if accessToken != "ghs_token_1" {
+		t.Fatalf("expected access token to equal 'ghs_token_1', got '%s'", accessToken)
+	}
+
+	// Cached token
+	accessToken, err = credentials.AccessToken()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if accessToken != "ghs_token_1" || nbOfExchanges != 1 {
+		t.Fatalf("expected cached access token, got '%s'", accessToken)
+	}
+
+	// Token about to expire
+	now = now.Add(time.Hour - appInstallationTokenRefreshMargin)
+
+	accessToken, err = credentials.AccessToken()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if accessToken != "ghs_token_2" {
+		t.Fatalf("expected refreshed access token, got '%s'", accessToken)
+	}
+}
@@ -0,0 +1,164 @@
+package github
+
+import "github.com/eleven-sh/eleven/entities"
+
+// DeployKeysRemovalHook is a pre-remove hook that
+// removes the deploy keys registered for an env.
+type DeployKeysRemovalHook struct {
+	service Service
+}
+
+func NewDeployKeysRemovalHook(
+	service Service,
+) DeployKeysRemovalHook {
+
+	return DeployKeysRemovalHook{
+		service: service,
+	}
+}
+
+func (d DeployKeysRemovalHook) Run(
+	cloudService entities.CloudService,
+	config *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	if !env.UsesDeployKeys() {
+		return nil
+	}
+
+	return d.service.RemoveDeployKeysForEnv(
+		env,
+	)
+}
+
+// DeployKeysCreator registers the deploy keys of an env
+// once its SSH key pair has been generated.
+type DeployKeysCreator struct {
+	service Service
+}
+
+func NewDeployKeysCreator(
+	service Service,
+) DeployKeysCreator {
+
+	return DeployKeysCreator{
+		service: service,
+	}
+}
+
+func (d DeployKeysCreator) Create(env *entities.Env) error {
+	if !env.UsesDeployKeys() {
+		return nil
+	}
+
+	return d.service.CreateDeployKeysForEnv(
+		env,
+	)
+}
+
+// SSHKeySwapper replaces the GitHub key of an env during
+// a key rotation. Depending on the env's GitHub SSH key scope,
+// the user key or the repositories deploy keys are replaced.
+type SSHKeySwapper struct {
+	service Service
+}
+
+func NewSSHKeySwapper(
+	service Service,
+) SSHKeySwapper {
+
+	return SSHKeySwapper{
+		service: service,
+	}
+}
+
+func (s SSHKeySwapper) Swap(
+	env *entities.Env,
+	oldPublicKeyContent string,
+	newPublicKeyContent string,
+) error {
+
+	if env.UsesDeployKeys() {
+		return s.service.SwapDeployKeysForEnv(
+			env,
+			newPublicKeyContent,
+		)
+	}
+
+	_, err := s.service.SwapSSHKey(
+		env.GetSSHKeyPairName(),
+		oldPublicKeyContent,
+		newPublicKeyContent,
+	)
+
+	return err
+}
+
+// SSHKeyRenamer ensures that the GitHub key of an env is still
+// registered after a rename. Depending on the env's GitHub SSH key
+// scope, the user key or the repositories deploy keys are checked.
+// Existing keys keep their title (see Service.RenameSSHKey).
+type SSHKeyRenamer struct {
+	service Service
+}
+
+func NewSSHKeyRenamer(
+	service Service,
+) SSHKeyRenamer {
+
+	return SSHKeyRenamer{
+		service: service,
+	}
+}
+
+func (s SSHKeyRenamer) Rename(
+	env *entities.Env,
+	oldKeyPairName string,
+) error {
+
+	if oldKeyPairName == env.GetSSHKeyPairName() {
+		return nil
+	}
+
+	if env.UsesDeployKeys() {
+		return s.service.RenameDeployKeysForEnv(
+			env,
+		)
+	}
+
+	publicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.service.RenameSSHKey(
+		env.GetSSHKeyPairName(),
+		publicKeyContent,
+	)
+
+	return err
+}
+
+// PublicKeysFetcher returns the public keys of the
+// GitHub users that are granted access to an env.
+type PublicKeysFetcher struct {
+	service Service
+}
+
+func NewPublicKeysFetcher(
+	service Service,
+) PublicKeysFetcher {
+
+	return PublicKeysFetcher{
+		service: service,
+	}
+}
+
+func (p PublicKeysFetcher) Fetch(username string) ([]string, error) {
+	return p.service.ListUserPublicKeys(
+		username,
+	)
+}
@@ -1,29 +1,103 @@
 package github
 
 import (
-	"context"
+	"net/http"
+	"net/url"
+	"strings"
 
 	gogithub "github.com/google/go-github/v43/github"
 	"golang.org/x/oauth2"
 )
 
-type Service struct{}
+// Service calls the GitHub API with the passed credentials.
+// An access token is requested for each request so that
+// expiring credentials (like app installations)
+// are refreshed during long-running operations.
+type Service struct {
+	credentials Credentials
+	baseURL     *url.URL
+}
+
+type options struct {
+	baseURL *url.URL
+}
+
+// Option configures the service and the credentials
+// that call the GitHub API.
+type Option func(*options)
+
+// WithBaseURL sets the URL of the GitHub API to call.
+// Used to reach GitHub Enterprise Server instances
+// (e.g. "https://github.example.com/api/v3/").
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(o *options) {
+		// The GitHub client resolves
+		// paths relative to the base URL
+		if !strings.HasSuffix(baseURL.Path, "/") {
+			baseURLCopy := *baseURL
+			baseURLCopy.Path += "/"
+			baseURL = &baseURLCopy
+		}
+
+		o.baseURL = baseURL
+	}
+}
+
+func buildOptions(opts []Option) options {
+	builtOptions := options{}
+
+	for _, opt := range opts {
+		opt(&builtOptions)
+	}
+
+	return builtOptions
+}
+
+func NewService(
+	credentials Credentials,
+	opts ...Option,
+) Service {
+
+	builtOptions := buildOptions(opts)
+
+	return Service{
+		credentials: credentials,
+		baseURL:     builtOptions.baseURL,
+	}
+}
+
+// credentialsTokenSource returns the current
+// access token of the credentials on each call
+type credentialsTokenSource struct {
+	credentials Credentials
+}
+
+func (c credentialsTokenSource) Token() (*oauth2.Token, error) {
+	accessToken, err := c.credentials.AccessToken()
+
+	if err != nil {
+		return nil, err
+	}
 
-func NewService() Service {
-	return Service{}
+	return &oauth2.Token{
+		AccessToken: accessToken,
+	}, nil
 }
 
-func (s Service) buildClient(accessToken string) *gogithub.Client {
-	oAuthTokenSource := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: accessToken,
+func (s Service) buildClient() *gogithub.Client {
+	// The token source is not wrapped in a "ReuseTokenSource"
+	// given that the credentials manage their own cache
+	client := gogithub.NewClient(&http.Client{
+		Transport: &oauth2.Transport{
+			Source: credentialsTokenSource{
+				credentials: s.credentials,
+			},
 		},
-	)
+	})
 
-	oAuthClient := oauth2.NewClient(
-		context.TODO(),
-		oAuthTokenSource,
-	)
+	if s.baseURL != nil {
+		client.BaseURL = s.baseURL
+	}
 
-	return gogithub.NewClient(oAuthClient)
+	return client
 }
@@ -13,18 +13,15 @@ type AuthenticatedUser struct {
 	FullName     string
 }
 
-func (s Service) GetAuthenticatedUser(
-	accessToken string,
-) (*AuthenticatedUser, error) {
-
-	client := s.buildClient(accessToken)
+func (s Service) GetAuthenticatedUser() (*AuthenticatedUser, error) {
+	client := s.buildClient()
 
 	var primaryEmail string
 	var getPrimaryEmailErr error
 	var getPrimaryEmailChan = make(chan struct{})
 
 	go func() {
-		primaryEmail, getPrimaryEmailErr = s.getAuthenticatedUserPrimaryEmail(accessToken)
+		primaryEmail, getPrimaryEmailErr = s.getAuthenticatedUserPrimaryEmail()
 
 		close(getPrimaryEmailChan)
 	}()
@@ -51,11 +48,8 @@ func (s Service) GetAuthenticatedUser(
 	}, nil
 }
 
-func (s Service) getAuthenticatedUserPrimaryEmail(
-	accessToken string,
-) (string, error) {
-
-	client := s.buildClient(accessToken)
+func (s Service) getAuthenticatedUserPrimaryEmail() (string, error) {
+	client := s.buildClient()
 
 	emails, _, err := client.Users.ListEmails(context.TODO(), nil)
 
@@ -66,6 +60,33 @@ func (s Service) getAuthenticatedUserPrimaryEmail(
 	return getPrimaryEmail(emails)
 }
 
+// botCredentials is implemented by the credentials
+// that don't belong to a GitHub user (like app installations)
+type botCredentials interface {
+	BotLogin() (string, error)
+}
+
+// GetAuthenticatedLogin returns the login of the user
+// (or of the app's bot user) that owns the credentials.
+func (s Service) GetAuthenticatedLogin() (string, error) {
+	if credentials, ok := s.credentials.(botCredentials); ok {
+		return credentials.BotLogin()
+	}
+
+	client := s.buildClient()
+
+	// Passing the empty string will
+	// fetch the authenticated user
+	userName := ""
+	user, _, err := client.Users.Get(context.TODO(), userName)
+
+	if err != nil {
+		return "", err
+	}
+
+	return user.GetLogin(), nil
+}
+
 func getPrimaryEmail(emails []*github.UserEmail) (string, error) {
 	for _, email := range emails {
 		if email.GetPrimary() {
@@ -2,6 +2,10 @@ package github
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/google/go-github/v43/github"
@@ -95,3 +99,56 @@ func TestGetPrimaryEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAuthenticatedLoginWithAppInstallationCredentials(t *testing.T) {
+	_, privateKeyPEMContent := generateTestAppPrivateKey(t)
+
+	nbOfAppRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Installation tokens cannot fetch the authenticated user
+		if r.Method != http.MethodGet || r.URL.Path != "/app" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		nbOfAppRequests++
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"slug": "eleven"}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	credentials, err := NewAppInstallationCredentials(
+		1234,
+		5678,
+		privateKeyPEMContent,
+		WithBaseURL(baseURL),
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	actorResolver := NewActorResolver(
+		NewService(credentials, WithBaseURL(baseURL)),
+	)
+
+	for i := 0; i < 2; i++ {
+		actor, err := actorResolver.ResolveActor()
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		if actor != "eleven[bot]" {
+			t.Fatalf("expected actor to equal 'eleven[bot]', got '%s'", actor)
+		}
+	}
+
+	if nbOfAppRequests != 1 {
+		t.Fatalf("expected app to be fetched once, got '%d'", nbOfAppRequests)
+	}
+}
@@ -0,0 +1,269 @@
+package github
+
+import (
+	"context"
+
+	"github.com/eleven-sh/eleven/entities"
+	"github.com/google/go-github/v43/github"
+)
+
+func (s Service) CreateDeployKey(
+	repositoryOwner string,
+	repositoryName string,
+	keyPairName string,
+	publicKeyContent string,
+	readOnly bool,
+) (*github.Key, error) {
+
+	client := s.buildClient()
+
+	key, _, err := client.Repositories.CreateKey(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		&github.Key{
+			Title:    &keyPairName,
+			Key:      &publicKeyContent,
+			ReadOnly: &readOnly,
+		},
+	)
+
+	return key, err
+}
+
+// findDeployKey returns the deploy key of the repository matching
+// the passed public key content or nil if there is none
+func (s Service) findDeployKey(
+	repositoryOwner string,
+	repositoryName string,
+	publicKeyContent string,
+) (*github.Key, error) {
+
+	client := s.buildClient()
+	listOpts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		keys, resp, err := client.Repositories.ListKeys(
+			context.TODO(),
+			repositoryOwner,
+			repositoryName,
+			listOpts,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if sshPublicKeysMatch(key.GetKey(), publicKeyContent) {
+				return key, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// ensureDeployKey creates the deploy key unless a key with the
+// same content is already registered on the repository (e.g. by
+// an interrupted operation). GitHub rejects duplicated keys.
+func (s Service) ensureDeployKey(
+	repositoryOwner string,
+	repositoryName string,
+	keyPairName string,
+	publicKeyContent string,
+	readOnly bool,
+) (*github.Key, error) {
+
+	deployKey, err := s.findDeployKey(
+		repositoryOwner,
+		repositoryName,
+		publicKeyContent,
+	)
+
+	if err != nil || deployKey != nil {
+		return deployKey, err
+	}
+
+	return s.CreateDeployKey(
+		repositoryOwner,
+		repositoryName,
+		keyPairName,
+		publicKeyContent,
+		readOnly,
+	)
+}
+
+func (s Service) RemoveDeployKey(
+	repositoryOwner string,
+	repositoryName string,
+	deployKeyID int64,
+) error {
+
+	client := s.buildClient()
+
+	_, err := client.Repositories.DeleteKey(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		deployKeyID,
+	)
+
+	return err
+}
+
+// CreateDeployKeysForEnv registers the env's SSH public key
+// as a deploy key on the env's repository if it doesn't have one.
+// The IDs of the created keys are set in the env's repositories
+// so the caller is responsible for saving the env in config.
+func (s Service) CreateDeployKeysForEnv(
+	env *entities.Env,
+) error {
+
+	err := env.CheckDeployKeysRepositories()
+
+	if err != nil {
+		return err
+	}
+
+	publicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		return err
+	}
+
+	for repoIndex, repo := range env.Repositories {
+		if repo.DeployKeyID != 0 {
+			continue
+		}
+
+		deployKey, err := s.ensureDeployKey(
+			repo.Owner,
+			repo.Name,
+			env.GetSSHKeyPairName(),
+			publicKeyContent,
+			env.HasReadOnlyDeployKeys(),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		env.Repositories[repoIndex].DeployKeyID = deployKey.GetID()
+	}
+
+	return nil
+}
+
+// RemoveDeployKeysForEnv removes the deploy keys created by
+// "CreateDeployKeysForEnv". Already removed keys are ignored.
+func (s Service) RemoveDeployKeysForEnv(
+	env *entities.Env,
+) error {
+
+	for repoIndex, repo := range env.Repositories {
+		if repo.DeployKeyID == 0 {
+			continue
+		}
+
+		err := s.RemoveDeployKey(
+			repo.Owner,
+			repo.Name,
+			repo.DeployKeyID,
+		)
+
+		if err != nil && !s.IsNotFoundError(err) {
+			return err
+		}
+
+		env.Repositories[repoIndex].DeployKeyID = 0
+	}
+
+	return nil
+}
+
+// SwapDeployKeysForEnv replaces the deploy keys of an env
+// with the passed public key. For each repository, the new
+// key is created before the old one is removed. The IDs are
+// updated in the env's repositories, even on error, so the
+// caller is responsible for saving the env in config.
+func (s Service) SwapDeployKeysForEnv(
+	env *entities.Env,
+	newPublicKeyContent string,
+) error {
+
+	for repoIndex, repo := range env.Repositories {
+		newDeployKey, err := s.ensureDeployKey(
+			repo.Owner,
+			repo.Name,
+			env.GetSSHKeyPairName(),
+			newPublicKeyContent,
+			env.HasReadOnlyDeployKeys(),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		// The key may already be swapped
+		// by an interrupted rotation
+		if repo.DeployKeyID != 0 && repo.DeployKeyID != newDeployKey.GetID() {
+			err = s.RemoveDeployKey(
+				repo.Owner,
+				repo.Name,
+				repo.DeployKeyID,
+			)
+
+			if err != nil && !s.IsNotFoundError(err) {
+				return err
+			}
+		}
+
+		env.Repositories[repoIndex].DeployKeyID = newDeployKey.GetID()
+	}
+
+	return nil
+}
+
+// RenameDeployKeysForEnv is called when the key pair of an env is
+// renamed. Like for user keys, deploy keys are kept with their
+// current title to never interrupt the access to the repositories.
+// Missing keys are created with the new title.
+func (s Service) RenameDeployKeysForEnv(
+	env *entities.Env,
+) error {
+
+	publicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		return err
+	}
+
+	for repoIndex, repo := range env.Repositories {
+		if repo.DeployKeyID == 0 {
+			continue
+		}
+
+		deployKey, err := s.ensureDeployKey(
+			repo.Owner,
+			repo.Name,
+			env.GetSSHKeyPairName(),
+			publicKeyContent,
+			env.HasReadOnlyDeployKeys(),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		env.Repositories[repoIndex].DeployKeyID = deployKey.GetID()
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eleven-sh/eleven/entities"
+)
+
+func TestRenameDeployKeysForEnv(t *testing.T) {
+	sshKeyPairPEMContent, err := entities.GenerateSSHKeyPairPEMContent()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	env := &entities.Env{
+		Name:                 "env",
+		SSHKeyPairPEMContent: sshKeyPairPEMContent,
+	}
+
+	publicKeyContent, err := env.GetSSHPublicKeyContent()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	testCases := []struct {
+		test                string
+		keysJSON            string
+		createStatusCode    int
+		expectedError       bool
+		expectedDeployKeyID int64
+	}{
+		{
+			test:                "with existing key",
+			keysJSON:            fmt.Sprintf(`[{"id": 1, "title": "old_title", "key": %q}]`, publicKeyContent),
+			createStatusCode:    http.StatusCreated,
+			expectedDeployKeyID: 1,
+		},
+
+		{
+			test:                "with missing key",
+			keysJSON:            `[]`,
+			createStatusCode:    http.StatusCreated,
+			expectedDeployKeyID: 2,
+		},
+
+		{
+			test:                "with missing key and failing creation",
+			keysJSON:            `[]`,
+			createStatusCode:    http.StatusUnprocessableEntity,
+			expectedError:       true,
+			expectedDeployKeyID: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			server := newFakeKeysServer("/repos/eleven-sh/eleven/keys", tc.keysJSON, tc.createStatusCode)
+			defer server.Close()
+
+			env.Repositories = []entities.EnvRepository{
+				{
+					Owner:       "eleven-sh",
+					Name:        "eleven",
+					DeployKeyID: 1,
+				},
+			}
+
+			err := server.buildService().RenameDeployKeysForEnv(env)
+
+			if tc.expectedError && err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if env.Repositories[0].DeployKeyID != tc.expectedDeployKeyID {
+				t.Fatalf(
+					"expected deploy key ID to equal '%d', got '%d'",
+					tc.expectedDeployKeyID,
+					env.Repositories[0].DeployKeyID,
+				)
+			}
+
+			// The access to the repository must never be interrupted
+			if server.nbOfRemovals != 0 {
+				t.Fatalf("expected no key removal, got '%d'", server.nbOfRemovals)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package github
 
-import "github.com/google/go-github/v43/github"
+import (
+	"errors"
+
+	"github.com/google/go-github/v43/github"
+)
+
+var ErrInvalidAppPrivateKey = errors.New("ErrInvalidAppPrivateKey")
 
 func (s Service) IsNotFoundError(err error) bool {
 	if githubErr, ok := err.(*github.ErrorResponse); ok &&
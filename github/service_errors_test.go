@@ -49,7 +49,7 @@ func TestServiceIsNotFoundError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
-			service := NewService()
+			service := NewService(NewOAuthCredentials(""))
 
 			ret := service.IsNotFoundError(tc.passedError)
 
@@ -105,7 +105,7 @@ func TestServiceIsInvalidAccessTokenError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.test, func(t *testing.T) {
-			service := NewService()
+			service := NewService(NewOAuthCredentials(""))
 
 			ret := service.IsInvalidAccessTokenError(tc.passedError)
 
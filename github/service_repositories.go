@@ -5,12 +5,11 @@ import (
 )
 
 func (s Service) DoesRepositoryExist(
-	accessToken string,
 	repositoryOwner string,
 	repositoryName string,
 ) (bool, error) {
 
-	client := s.buildClient(accessToken)
+	client := s.buildClient()
 
 	repository, _, err := client.Repositories.Get(
 		context.TODO(),
@@ -2,17 +2,17 @@ package github
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/go-github/v43/github"
 )
 
 func (s Service) CreateSSHKey(
-	accessToken string,
 	keyPairName string,
 	publicKeyContent string,
 ) (*github.Key, error) {
 
-	client := s.buildClient(accessToken)
+	client := s.buildClient()
 
 	key, _, err := client.Users.CreateKey(
 		context.TODO(),
@@ -26,11 +26,10 @@ func (s Service) CreateSSHKey(
 }
 
 func (s Service) RemoveSSHKey(
-	accessToken string,
 	sshKeyID int64,
 ) error {
 
-	client := s.buildClient(accessToken)
+	client := s.buildClient()
 
 	_, err := client.Users.DeleteKey(
 		context.TODO(),
@@ -39,3 +38,171 @@ func (s Service) RemoveSSHKey(
 
 	return err
 }
+
+// SwapSSHKey registers the new public key before removing the
+// old one so that the access to GitHub is never interrupted.
+// Keys are looked up by content given that their IDs are not known.
+// The new key is reused if it was registered by an interrupted swap.
+func (s Service) SwapSSHKey(
+	keyPairName string,
+	oldPublicKeyContent string,
+	newPublicKeyContent string,
+) (*github.Key, error) {
+
+	newKey, err := s.findSSHKey(
+		newPublicKeyContent,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if newKey == nil {
+		newKey, err = s.CreateSSHKey(
+			keyPairName,
+			newPublicKeyContent,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	oldKey, err := s.findSSHKey(
+		oldPublicKeyContent,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if oldKey == nil { // Already removed
+		return newKey, nil
+	}
+
+	err = s.RemoveSSHKey(oldKey.GetID())
+
+	if err != nil && !s.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	return newKey, nil
+}
+
+// RenameSSHKey is called when the key pair of an env is renamed.
+// GitHub keys could not be updated and a key could not be registered
+// twice so renaming a key would require to remove it first, which
+// would interrupt the access to GitHub (or lose it if the creation
+// fails). The key is therefore kept with its current title and only
+// created, with the new title, if it is missing.
+func (s Service) RenameSSHKey(
+	keyPairName string,
+	publicKeyContent string,
+) (*github.Key, error) {
+
+	key, err := s.findSSHKey(
+		publicKeyContent,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key != nil {
+		return key, nil
+	}
+
+	return s.CreateSSHKey(
+		keyPairName,
+		publicKeyContent,
+	)
+}
+
+// ListUserPublicKeys returns the public SSH
+// keys registered on the passed user's account
+func (s Service) ListUserPublicKeys(
+	userName string,
+) ([]string, error) {
+
+	client := s.buildClient()
+	listOpts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	publicKeys := []string{}
+
+	for {
+		keys, resp, err := client.Users.ListKeys(
+			context.TODO(),
+			userName,
+			listOpts,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			publicKeys = append(publicKeys, key.GetKey())
+		}
+
+		if resp.NextPage == 0 {
+			return publicKeys, nil
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// findSSHKey returns the authenticated user key matching
+// the passed public key content or nil if there is none
+func (s Service) findSSHKey(
+	publicKeyContent string,
+) (*github.Key, error) {
+
+	client := s.buildClient()
+	listOpts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		// Passing the empty string will
+		// list the authenticated user keys
+		userName := ""
+		keys, resp, err := client.Users.ListKeys(
+			context.TODO(),
+			userName,
+			listOpts,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if sshPublicKeysMatch(key.GetKey(), publicKeyContent) {
+				return key, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// sshPublicKeysMatch compares the algorithm and the
+// key content, ignoring comments (GitHub strips them).
+func sshPublicKeysMatch(publicKeyA, publicKeyB string) bool {
+	publicKeyAParts := strings.Fields(publicKeyA)
+	publicKeyBParts := strings.Fields(publicKeyB)
+
+	if len(publicKeyAParts) < 2 || len(publicKeyBParts) < 2 {
+		return false
+	}
+
+	return publicKeyAParts[0] == publicKeyBParts[0] &&
+		publicKeyAParts[1] == publicKeyBParts[1]
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSSHPublicKeysMatch(t *testing.T) {
+	testCases := []struct {
+		test           string
+		publicKeyA     string
+		publicKeyB     string
+		expectedReturn bool
+	}{
+		{
+			test:           "with same keys",
+			publicKeyA:     "ssh-rsa AAAAB3NzaC1yc2E",
+			publicKeyB:     "ssh-rsa AAAAB3NzaC1yc2E",
+			expectedReturn: true,
+		},
+
+		{
+			test:           "with same keys and different comments",
+			publicKeyA:     "ssh-rsa AAAAB3NzaC1yc2E eleven/env",
+			publicKeyB:     "ssh-rsa AAAAB3NzaC1yc2E",
+			expectedReturn: true,
+		},
+
+		{
+			test:           "with different algorithms",
+			publicKeyA:     "ssh-rsa AAAAB3NzaC1yc2E",
+			publicKeyB:     "ssh-ed25519 AAAAB3NzaC1yc2E",
+			expectedReturn: false,
+		},
+
+		{
+			test:           "with different keys",
+			publicKeyA:     "ssh-rsa AAAAB3NzaC1yc2E",
+			publicKeyB:     "ssh-rsa AAAAC3NzaC1lZDI1NTE5",
+			expectedReturn: false,
+		},
+
+		{
+			test:           "with empty key",
+			publicKeyA:     "",
+			publicKeyB:     "",
+			expectedReturn: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			ret := sshPublicKeysMatch(tc.publicKeyA, tc.publicKeyB)
+
+			if ret != tc.expectedReturn {
+				t.Fatalf(
+					"expected return to equal '%v', got '%v'",
+					tc.expectedReturn,
+					ret,
+				)
+			}
+		})
+	}
+}
+
+func TestRenameSSHKey(t *testing.T) {
+	publicKeyContent := "ssh-rsa AAAAB3NzaC1yc2E"
+
+	testCases := []struct {
+		test                  string
+		keysJSON              string
+		createStatusCode      int
+		expectedError         bool
+		expectedNbOfCreations int
+	}{
+		{
+			test:                  "with existing key",
+			keysJSON:              fmt.Sprintf(`[{"id": 1, "title": "old_title", "key": "%s"}]`, publicKeyContent),
+			createStatusCode:      http.StatusCreated,
+			expectedNbOfCreations: 0,
+		},
+
+		{
+			test:                  "with missing key",
+			keysJSON:              `[]`,
+			createStatusCode:      http.StatusCreated,
+			expectedNbOfCreations: 1,
+		},
+
+		{
+			test:                  "with missing key and failing creation",
+			keysJSON:              `[]`,
+			createStatusCode:      http.StatusUnprocessableEntity,
+			expectedError:         true,
+			expectedNbOfCreations: 1,
+		},
+
+		{
+			test:                  "with existing key and failing creation",
+			keysJSON:              fmt.Sprintf(`[{"id": 1, "title": "old_title", "key": "%s"}]`, publicKeyContent),
+			createStatusCode:      http.StatusUnprocessableEntity,
+			expectedNbOfCreations: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			server := newFakeKeysServer("/user/keys", tc.keysJSON, tc.createStatusCode)
+			defer server.Close()
+
+			_, err := server.buildService().RenameSSHKey("new_title", publicKeyContent)
+
+			if tc.expectedError && err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if server.nbOfCreations != tc.expectedNbOfCreations {
+				t.Fatalf(
+					"expected number of creations to equal '%d', got '%d'",
+					tc.expectedNbOfCreations,
+					server.nbOfCreations,
+				)
+			}
+
+			// The access to GitHub must never be interrupted
+			if server.nbOfRemovals != 0 {
+				t.Fatalf("expected no key removal, got '%d'", server.nbOfRemovals)
+			}
+		})
+	}
+}
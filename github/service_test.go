@@ -0,0 +1,113 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type countingCredentials struct {
+	nbOfCalls int
+}
+
+func (c *countingCredentials) AccessToken() (string, error) {
+	c.nbOfCalls++
+	return fmt.Sprintf("token_%d", c.nbOfCalls), nil
+}
+
+func TestServiceRequestsAccessTokenOnEachRequest(t *testing.T) {
+	receivedAuthorizations := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v3/user" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		receivedAuthorizations = append(
+			receivedAuthorizations,
+			r.Header.Get("Authorization"),
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"login": "jeremylevy"}`)
+	}))
+	defer server.Close()
+
+	// The trailing slash is added by the option
+	baseURL, _ := url.Parse(server.URL + "/api/v3")
+	credentials := &countingCredentials{}
+
+	service := NewService(credentials, WithBaseURL(baseURL))
+
+	for i := 0; i < 2; i++ {
+		login, err := service.GetAuthenticatedLogin()
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		if login != "jeremylevy" {
+			t.Fatalf("expected login to equal 'jeremylevy', got '%s'", login)
+		}
+	}
+
+	expectedAuthorizations := []string{"Bearer token_1", "Bearer token_2"}
+
+	if fmt.Sprint(receivedAuthorizations) != fmt.Sprint(expectedAuthorizations) {
+		t.Fatalf(
+			"expected authorizations to equal '%+v', got '%+v'",
+			expectedAuthorizations,
+			receivedAuthorizations,
+		)
+	}
+}
+
+// fakeKeysServer serves the keys listed at the passed path and
+// records the creations and removals made by the service
+type fakeKeysServer struct {
+	*httptest.Server
+	nbOfCreations int
+	nbOfRemovals  int
+}
+
+func newFakeKeysServer(
+	keysPath string,
+	keysJSON string,
+	createStatusCode int,
+) *fakeKeysServer {
+
+	fakeServer := &fakeKeysServer{}
+
+	fakeServer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == keysPath:
+			fmt.Fprint(w, keysJSON)
+		case r.Method == http.MethodPost && r.URL.Path == keysPath:
+			fakeServer.nbOfCreations++
+			w.WriteHeader(createStatusCode)
+			fmt.Fprint(w, `{"id": 2, "title": "new_title"}`)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, keysPath+"/"):
+			fakeServer.nbOfRemovals++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return fakeServer
+}
+
+func (f *fakeKeysServer) buildService() Service {
+	baseURL, _ := url.Parse(f.URL)
+
+	return NewService(
+		NewOAuthCredentials("gho_token"),
+		WithBaseURL(baseURL),
+	)
+}